@@ -18,6 +18,7 @@ type CLI struct {
 	// Commands
 	Config  ConfigCmd  `cmd:"" name:"config"   aliases:"c"  group:"Generic Commands" help:"Manage config."`
 	Version VersionCmd `cmd:"" name:"version"               group:"Generic Commands" help:"Show version information."`
+	Audit   AuditCmd   `cmd:"" name:"audit"                 group:"Generic Commands" help:"Show the audit log of mutating requests."`
 	Buckets BucketsCmd `cmd:"" name:"buckets"  aliases:"ls" group:"Bucket Commands"  help:"List all buckets."`
 	Bucket  BucketCmd  `cmd:"" name:"bucket"   aliases:"b"  group:"Bucket Commands"  help:"Manage bucket and objects."`
 
@@ -32,11 +33,30 @@ type CLI struct {
 	SecretKey  string            `name:"secret-key"                            help:"S3 secret key."`
 	Insecure   bool              `name:"insecure"                              help:"Skip TLS verification."`
 	ReadOnly   bool              `name:"read-only"                             help:"Only allow safe HTTP methods (HEAD, GET, OPTIONS)."`
-	Network    string            `name:"network"             default:"tcp"     help:"Force IPv4/6 with 'tcp4' or 'tcp6'."`
+	Yes        bool              `name:"yes"       short:"y"                   help:"Skip confirmation prompts of destructive commands."`
+	Network    string            `name:"network"                               help:"Force IPv4/6 with 'tcp4' or 'tcp6' (default: tcp)."`
 	Bandwidth  string            `name:"bandwidth"                             help:"Limit bandwith per second, e.g. '1 MiB' (always 64 KiB burst)."`
+	UploadBW   string            `name:"upload-bandwidth"                      help:"Limit upload bandwith per second, overrides --bandwidth."`
+	DownloadBW string            `name:"download-bandwidth"                    help:"Limit download bandwith per second, overrides --bandwidth."`
 	Headers    map[string]string `name:"header"                                help:"Set HTTP headers (format: 'key1=val1;key2=val2')."`
 	Params     map[string]string `name:"param"                                 help:"Set URL parameters (format: 'key1=val1;key2=val2')."`
 	SNI        string            `name:"sni"                                   help:"TLS Server Name Indication."`
+	CAFile     string            `name:"ca-file"                               help:"PEM file with additional CA certificates."`
+	ClientCert string            `name:"client-cert"                           help:"PEM file with the TLS client certificate."`
+	ClientKey  string            `name:"client-key"                            help:"PEM file with the TLS client key."`
+	TLSMin     string            `name:"tls-min-version"                       help:"Minimum TLS version ('1.0', '1.1', '1.2', '1.3')."`
+	Proxy      string            `name:"proxy"                                 help:"Proxy URL, e.g. 'socks5://localhost:1080' or 'http://proxy:3128'."`
+	NoProxy    string            `name:"no-proxy"                              help:"Comma-separated hosts, domains and CIDRs which bypass the proxy."`
+
+	MaxRetries      *int          `name:"max-retries"       help:"Maximum number of retries per request (0 disables retries)."`
+	RetryMode       string        `name:"retry-mode"        help:"Retry mode ('standard' or 'adaptive')."`
+	MaxBackoff      time.Duration `name:"max-backoff"       help:"Maximum delay between retries, e.g. '20s'."`
+	ConnectTimeout  time.Duration `name:"connect-timeout"   help:"Timeout for establishing a connection, e.g. '5s'."`
+	RequestTimeout  time.Duration `name:"request-timeout"   help:"Timeout for the response headers after sending a request, e.g. '1m'."`
+	IdleReadTimeout time.Duration `name:"idle-read-timeout" help:"Abort and retry when no data was received for the given duration."`
+
+	RequestsPerSecond float64 `name:"requests-per-second" help:"Limit the number of HTTP requests per second."`
+	RequestBurst      int     `name:"request-burst"       help:"Number of requests allowed to exceed the request limit at once (default: 1)."`
 }
 
 type ArgPath struct {
@@ -55,6 +75,10 @@ type ArgUploadID struct {
 	UploadID string `arg:"" name:"upload-id"`
 }
 
+type ArgPartNumber struct {
+	PartNumber int32 `arg:"" name:"part-number"`
+}
+
 type ArgPrefix struct {
 	Prefix string `arg:"" name:"prefix" optional:""`
 }
@@ -84,7 +108,24 @@ type FlagRange struct {
 }
 
 type FlagVersionID struct {
-	VersionID string `name:"version" help:"Version ID"`
+	VersionID string `name:"version" aliases:"version-id" help:"Version ID"`
+}
+
+type flagsContent struct {
+	ContentType        string   `name:"content-type"        help:"Content-Type (default: detected by extension and content)."`
+	CacheControl       string   `name:"cache-control"       help:"Cache-Control header, e.g. 'max-age=3600'."`
+	ContentDisposition string   `name:"content-disposition" help:"Content-Disposition header, e.g. 'attachment'."`
+	ContentEncoding    string   `name:"content-encoding"    help:"Content-Encoding header, e.g. 'gzip'."`
+	ContentLanguage    string   `name:"content-language"    help:"Content-Language header, e.g. 'en-US'."`
+	Metadata           []string `name:"meta"                sep:"none" help:"User metadata in the format 'key=value', can be repeated."`
+}
+
+type flagStorageClass struct {
+	StorageClass string `name:"storage-class" help:"Storage class, e.g. 'STANDARD_IA', 'GLACIER' or 'DEEP_ARCHIVE'."`
+}
+
+type flagTags struct {
+	Tags []string `name:"tag" sep:"none" help:"Object tag in the format 'key=value', can be repeated."`
 }
 
 type flagsSSEC struct {
@@ -92,6 +133,25 @@ type flagsSSEC struct {
 	Key  string `name:"sse-c-key" help:"32 bytes key for AES256"`
 }
 
+type flagsSrcSSEC struct {
+	Algo string `name:"src-sse-c-algorithm" default:"AES256"`
+	Key  string `name:"src-sse-c-key" help:"32 bytes key for AES256 of the source object"`
+}
+
+type flagsSSE struct {
+	SSE          string `name:"sse"            help:"Server-side encryption ('AES256' or 'aws:kms')."`
+	SSEKMSKeyID  string `name:"sse-kms-key-id" help:"KMS key ID for 'aws:kms' encryption."`
+	SSEBucketKey bool   `name:"sse-bucket-key" help:"Use an S3 bucket key for 'aws:kms' encryption."`
+}
+
+func (f flagsSSE) config() controller.SSEConfig {
+	return controller.SSEConfig{
+		Algorithm: f.SSE,
+		KMSKeyID:  f.SSEKMSKeyID,
+		BucketKey: f.SSEBucketKey,
+	}
+}
+
 type flagExpiresIn struct {
 	FlagExpiresIn time.Duration `name:"epxires-in"`
 }
@@ -187,6 +247,18 @@ func (s VersionCmd) Run(cli CLI, ctrl *controller.Controller, config controller.
 	return nil
 }
 
+type AuditCmd struct {
+	AuditList AuditList `cmd:"" name:"ls" help:"List audit log entries."`
+}
+
+type AuditList struct {
+	FlagJson
+}
+
+func (s AuditList) Run(cli CLI, ctrl *controller.Controller) error {
+	return ctrl.AuditList(s.FlagJson.AsJson)
+}
+
 type BucketsCmd struct{}
 
 func (s BucketsCmd) Run(cli CLI, ctrl *controller.Controller) error {
@@ -206,21 +278,36 @@ type BucketArg struct {
 	BucketPolicy     BucketPolicy     `cmd:"" group:"Bucket Commands"    name:"policy"                     help:"Manage bucket policy."`
 	BucketCors       BucketCors       `cmd:"" group:"Bucket Commands"    name:"cors"                       help:"Manage CORS policy."`
 	BucketTag        BucketTag        `cmd:"" group:"Bucket Commands"    name:"tag"                        help:"Manage bucket tags."`
+	BucketACL        BucketACL        `cmd:"" group:"Bucket Commands"    name:"bucket-acl"                 help:"Manage bucket ACL."`
 	BucketLifecycle  BucketLifecycle  `cmd:"" group:"Bucket Commands"    name:"lifecycle"   aliases:"lc"   help:"Manage lifecycle policy."`
 	BucketVersioning BucketVersioning `cmd:"" group:"Bucket Commands"    name:"versioning"                 help:"Manage bucket versioning."`
+	BucketEncryption BucketEncryption `cmd:"" group:"Bucket Commands"    name:"encryption"                 help:"Manage default encryption."`
+	Notification     Notification     `cmd:"" group:"Bucket Commands"    name:"notification"               help:"Manage event notifications."`
+	Replication      Replication      `cmd:"" group:"Bucket Commands"    name:"replication"                help:"Manage bucket replication."`
+	Website          Website          `cmd:"" group:"Bucket Commands"    name:"website"                    help:"Manage static website hosting."`
+	BucketLogging    BucketLogging    `cmd:"" group:"Bucket Commands"    name:"logging"                    help:"Manage access logging."`
+	PublicAccess     PublicAccess     `cmd:"" group:"Bucket Commands"    name:"public-access-block" aliases:"pab" help:"Manage public access block."`
+	BucketOwnership  BucketOwnership  `cmd:"" group:"Bucket Commands"    name:"ownership"                  help:"Manage object ownership."`
 	BucketCleanup    BucketCleanup    `cmd:"" group:"Bucket Commands"    name:"cleanup"                    help:"Remove all objects versions and multiparts from the bucket."`
 	ObjectLock       ObjectLock       `cmd:"" group:"Bucket Commands"    name:"object-lock" aliases:"ol"   help:"Manage bucket object-locking."`
 	BucketSize       BucketSize       `cmd:"" group:"Bucket Commands"    name:"size"                       help:"Calculate bucket size (resource heavy!)"`
 	ObjectList       ObjectList       `cmd:"" group:"Object Commands"    name:"ls"                         help:"List objects."`
-	ObjectCopy       ObjectCopy       `cmd:"" group:"Object Commands"    name:"cp"                         help:"Server-side copy."`
+	ObjectCopy       ObjectCopy       `cmd:"" group:"Object Commands"    name:"cp"                         help:"Server-side copy, recursively when the key ends with the delimiter."`
 	ObjectPut        ObjectPut        `cmd:"" group:"Object Commands"    name:"put"                        help:"Upload object(s)."`
 	ObjectPutRand    ObjectPutRand    `cmd:"" group:"Object Commands"    name:"put-rand"                   help:"Upload random object(s)."`
 	ObjectDelete     ObjectDelete     `cmd:"" group:"Object Commands"    name:"rm"                         help:"Remove object."`
 	ObjectGet        ObjectGet        `cmd:"" group:"Object Commands"    name:"get"                        help:"Download object(s). Requires HeadObject permission."`
 	ObcectHead       ObjectHead       `cmd:"" group:"Object Commands"    name:"head"                       help:"Head Object Liss object information."`
 	ObjectVersions   ObjectVersions   `cmd:"" group:"Object Commands"    name:"versions"                   help:"List object versions"`
+	ObjectUndelete   ObjectUndelete   `cmd:"" group:"Object Commands"    name:"undelete"                   help:"Restore deleted objects in versioned buckets."`
 	ObjectPresign    ObjectPresign    `cmd:"" group:"Object Commands"    name:"presign"                    help:"Create pre-signed URLs."`
 	ObjectACL        ObjectACL        `cmd:"" group:"Object Commands"    name:"acl"                        help:"Manage object ACLs."`
+	ObjectTag        ObjectTag        `cmd:"" group:"Object Commands"    name:"object-tag"                 help:"Manage object tags."`
+	ObjectRetention  ObjectRetention  `cmd:"" group:"Object Commands"    name:"retention"                  help:"Manage object retention."`
+	ObjectLegalHold  ObjectLegalHold  `cmd:"" group:"Object Commands"    name:"legal-hold"                 help:"Manage object legal hold."`
+	ObjectSelect     ObjectSelect     `cmd:"" group:"Object Commands"    name:"select"                     help:"Query CSV, JSON or Parquet objects with SQL (S3 Select)."`
+	ObjectRestore    ObjectRestore    `cmd:"" group:"Object Commands"    name:"restore"                    help:"Restore archived objects, recursively when the key ends with the delimiter."`
+	Trash            Trash            `cmd:"" group:"Object Commands"    name:"trash"                      help:"Manage objects deleted with --trash."`
 	Multiparts       Multipart        `cmd:"" group:"Multipart Commands" name:"multipart"   aliases:"mp"   help:"Manage multipart uploads."`
 }
 
@@ -260,8 +347,46 @@ func (s ObjectVersions) Run(cli CLI, ctrl *controller.Controller) error {
 	)
 }
 
+type ObjectUndelete struct {
+	ArgObject
+	AsOf time.Time `name:"as-of" help:"Restore the versions which were current at the given time (RFC3339), e.g. '2025-11-22T14:00:00Z'."`
+	FlagConcurrency
+	FlagDryRun
+	flagDelimiter
+}
+
+func (s ObjectUndelete) Run(cli CLI, ctrl *controller.Controller) error {
+	return ctrl.ObjectUndelete(
+		s.ArgObject.Object,
+		controller.ObjectUndeleteConfig{
+			Bucket:      cli.Bucket.BucketArg.BucketName,
+			Delimiter:   s.flagDelimiter.Delimiter,
+			AsOf:        s.AsOf,
+			Concurrency: s.FlagConcurrency.Concurrency,
+			DryRun:      s.FlagDryRun.DryRun,
+		},
+	)
+}
+
+type flagsACL struct {
+	Canned           string   `name:"canned"             help:"Canned ACL, e.g. 'private' or 'public-read'."`
+	File             string   `name:"file"               help:"JSON file with the access control policy (Owner and Grants)."`
+	GrantRead        []string `name:"grant-read"         sep:"none" help:"Grant read access, e.g. 'id=<canonical-id>' or 'uri=http://acs.amazonaws.com/groups/global/AllUsers'."`
+	GrantFullControl []string `name:"grant-full-control" sep:"none" help:"Grant full control, e.g. 'id=<canonical-id>' or 'emailAddress=<email>'."`
+}
+
+func (f flagsACL) config() controller.ACLPutConfig {
+	return controller.ACLPutConfig{
+		Canned:           f.Canned,
+		FilePath:         f.File,
+		GrantRead:        f.GrantRead,
+		GrantFullControl: f.GrantFullControl,
+	}
+}
+
 type ObjectACL struct {
 	ObjectACLGet ObjectACLGet `cmd:"" name:"get" help:"Get object ACL."`
+	ObjectACLPut ObjectACLPut `cmd:"" name:"put" help:"Put object ACL, recursively when the object ends with the delimiter."`
 }
 
 type ObjectACLGet struct {
@@ -277,6 +402,242 @@ func (s ObjectACLGet) Run(cli CLI, ctrl *controller.Controller) error {
 	)
 }
 
+type ObjectACLPut struct {
+	ArgObject
+	flagsACL
+	FlagVersionID
+	FlagConcurrency
+	FlagDryRun
+	flagDelimiter
+}
+
+func (s ObjectACLPut) Run(cli CLI, ctrl *controller.Controller) error {
+	return ctrl.ObjectACLPut(
+		s.ArgObject.Object,
+		controller.ObjectACLPutConfig{
+			ACLPutConfig: s.flagsACL.config(),
+			Bucket:       cli.Bucket.BucketArg.BucketName,
+			VersionID:    s.FlagVersionID.VersionID,
+			Delimiter:    s.flagDelimiter.Delimiter,
+			Concurrency:  s.FlagConcurrency.Concurrency,
+			DryRun:       s.FlagDryRun.DryRun,
+		},
+	)
+}
+
+type ObjectTag struct {
+	ObjectTagGet    ObjectTagGet    `cmd:"" name:"get"               help:"Get object tags."`
+	ObjectTagPut    ObjectTagPut    `cmd:"" name:"put" aliases:"set" help:"Put object tags, recursively when the key ends with the delimiter."`
+	ObjectTagRemove ObjectTagRemove `cmd:"" name:"rm"                help:"Delete the given or all object tags, recursively when the key ends with the delimiter."`
+}
+
+type ObjectTagGet struct {
+	ArgObject
+	FlagVersionID
+}
+
+func (s ObjectTagGet) Run(cli CLI, ctrl *controller.Controller) error {
+	return ctrl.ObjectTagging(
+		cli.Bucket.BucketArg.BucketName,
+		s.ArgObject.Object,
+		s.FlagVersionID.VersionID,
+	)
+}
+
+type ObjectTagPut struct {
+	ArgObject
+	Tags  []string `arg:"" name:"key=value"`
+	Merge bool     `name:"merge" help:"Only add or update the given tags and keep all other tags."`
+	FlagVersionID
+	FlagConcurrency
+	FlagDryRun
+	flagDelimiter
+}
+
+func (s ObjectTagPut) Run(cli CLI, ctrl *controller.Controller) error {
+	return ctrl.ObjectTaggingPut(
+		s.ArgObject.Object,
+		controller.ObjectTaggingConfig{
+			Bucket:      cli.Bucket.BucketArg.BucketName,
+			VersionID:   s.FlagVersionID.VersionID,
+			Delimiter:   s.flagDelimiter.Delimiter,
+			Concurrency: s.FlagConcurrency.Concurrency,
+			DryRun:      s.FlagDryRun.DryRun,
+			Tags:        s.Tags,
+			Merge:       s.Merge,
+		},
+	)
+}
+
+type ObjectTagRemove struct {
+	ArgObject
+	Keys []string `arg:"" name:"key" optional:""`
+	FlagVersionID
+	FlagConcurrency
+	FlagDryRun
+	flagDelimiter
+}
+
+func (s ObjectTagRemove) Run(cli CLI, ctrl *controller.Controller) error {
+	return ctrl.ObjectTaggingDelete(
+		s.ArgObject.Object,
+		controller.ObjectTaggingConfig{
+			Bucket:      cli.Bucket.BucketArg.BucketName,
+			VersionID:   s.FlagVersionID.VersionID,
+			Delimiter:   s.flagDelimiter.Delimiter,
+			Concurrency: s.FlagConcurrency.Concurrency,
+			DryRun:      s.FlagDryRun.DryRun,
+			Tags:        s.Keys,
+		},
+	)
+}
+
+type ObjectSelect struct {
+	ArgObject
+	SQL          string `name:"sql"           required:""     help:"SQL expression, e.g. 'SELECT * FROM s3object s LIMIT 10'."`
+	Input        string `name:"input"                         help:"Input format ('csv', 'json' or 'parquet', default: detected by extension)."`
+	Output       string `name:"output"                        help:"Output format ('csv' or 'json', default: input format)."`
+	Compression  string `name:"compression"                   help:"Input compression ('NONE', 'GZIP' or 'BZIP2', default: detected by extension)."`
+	CSVHeader    string `name:"csv-header"    default:"USE"   help:"CSV header handling ('USE', 'IGNORE' or 'NONE')."`
+	CSVDelimiter string `name:"csv-delimiter" default:","     help:"CSV field delimiter."`
+	JSONType     string `name:"json-type"     default:"LINES" help:"JSON input type ('LINES' or 'DOCUMENT')."`
+	flagsSSEC
+}
+
+func (s ObjectSelect) Run(cli CLI, ctrl *controller.Controller) error {
+	return ctrl.ObjectSelect(
+		s.ArgObject.Object,
+		controller.ObjectSelectConfig{
+			Bucket:       cli.Bucket.BucketArg.BucketName,
+			SQL:          s.SQL,
+			Input:        s.Input,
+			Output:       s.Output,
+			Compression:  s.Compression,
+			CSVHeader:    s.CSVHeader,
+			CSVDelimiter: s.CSVDelimiter,
+			JSONType:     s.JSONType,
+			SSEC:         util.NewSSEC(s.flagsSSEC.Algo, s.flagsSSEC.Key),
+		},
+	)
+}
+
+type ObjectRestore struct {
+	ArgObject
+	Days         int32         `name:"days"          default:"1"  help:"Number of days the restored copy is available."`
+	Tier         string        `name:"tier"                       help:"Retrieval tier ('Standard', 'Bulk' or 'Expedited')."`
+	Wait         bool          `name:"wait"                       help:"Wait until all restores are completed."`
+	PollInterval time.Duration `name:"poll-interval" default:"1m" help:"Interval for checking the restore status with --wait."`
+	FlagVersionID
+	FlagConcurrency
+	FlagDryRun
+	flagDelimiter
+}
+
+func (s ObjectRestore) Run(cli CLI, ctrl *controller.Controller) error {
+	return ctrl.ObjectRestore(
+		s.ArgObject.Object,
+		controller.ObjectRestoreConfig{
+			Bucket:       cli.Bucket.BucketArg.BucketName,
+			VersionID:    s.FlagVersionID.VersionID,
+			Delimiter:    s.flagDelimiter.Delimiter,
+			Concurrency:  s.FlagConcurrency.Concurrency,
+			DryRun:       s.FlagDryRun.DryRun,
+			Days:         s.Days,
+			Tier:         s.Tier,
+			Wait:         s.Wait,
+			PollInterval: s.PollInterval,
+		},
+	)
+}
+
+type ObjectRetention struct {
+	ObjectRetentionGet ObjectRetentionGet `cmd:"" name:"get" help:"Get object retention."`
+	ObjectRetentionPut ObjectRetentionPut `cmd:"" name:"put" help:"Put object retention, recursively when the key ends with the delimiter."`
+}
+
+type ObjectRetentionGet struct {
+	ArgObject
+	FlagVersionID
+}
+
+func (s ObjectRetentionGet) Run(cli CLI, ctrl *controller.Controller) error {
+	return ctrl.ObjectRetentionGet(
+		cli.Bucket.BucketArg.BucketName,
+		s.ArgObject.Object,
+		s.FlagVersionID.VersionID,
+	)
+}
+
+type ObjectRetentionPut struct {
+	ArgObject
+	Mode  string    `name:"mode"  required:"" help:"Retention mode (GOVERNANCE or COMPLIANCE)."`
+	Until time.Time `name:"until" help:"Retain until the given time (RFC 3339), e.g. '2030-01-01T00:00:00Z'."`
+	For   string    `name:"for"   help:"Retain for the given duration from now, e.g. '30d' or '12h'."`
+	FlagVersionID
+	FlagBypassGovernance
+	FlagConcurrency
+	FlagDryRun
+	flagDelimiter
+}
+
+func (s ObjectRetentionPut) Run(cli CLI, ctrl *controller.Controller) error {
+	return ctrl.ObjectRetentionPut(
+		s.ArgObject.Object,
+		controller.ObjectRetentionConfig{
+			Bucket:           cli.Bucket.BucketArg.BucketName,
+			VersionID:        s.FlagVersionID.VersionID,
+			Delimiter:        s.flagDelimiter.Delimiter,
+			Concurrency:      s.FlagConcurrency.Concurrency,
+			DryRun:           s.FlagDryRun.DryRun,
+			Mode:             s.Mode,
+			Until:            s.Until,
+			For:              s.For,
+			BypassGovernance: s.FlagBypassGovernance.BypassGovernance,
+		},
+	)
+}
+
+type ObjectLegalHold struct {
+	ObjectLegalHoldGet ObjectLegalHoldGet `cmd:"" name:"get" help:"Get object legal hold."`
+	ObjectLegalHoldOn  ObjectLegalHoldSet `cmd:"" name:"on"  help:"Enable legal hold, recursively when the key ends with the delimiter."`
+	ObjectLegalHoldOff ObjectLegalHoldSet `cmd:"" name:"off" help:"Disable legal hold, recursively when the key ends with the delimiter."`
+}
+
+type ObjectLegalHoldGet struct {
+	ArgObject
+	FlagVersionID
+}
+
+func (s ObjectLegalHoldGet) Run(cli CLI, ctrl *controller.Controller) error {
+	return ctrl.ObjectLegalHoldGet(
+		cli.Bucket.BucketArg.BucketName,
+		s.ArgObject.Object,
+		s.FlagVersionID.VersionID,
+	)
+}
+
+type ObjectLegalHoldSet struct {
+	ArgObject
+	FlagVersionID
+	FlagConcurrency
+	FlagDryRun
+	flagDelimiter
+}
+
+func (s ObjectLegalHoldSet) Run(kctx *kong.Context, cli CLI, ctrl *controller.Controller) error {
+	return ctrl.ObjectLegalHoldPut(
+		s.ArgObject.Object,
+		kctx.Selected().Name == "on",
+		controller.ObjectLegalHoldConfig{
+			Bucket:      cli.Bucket.BucketArg.BucketName,
+			VersionID:   s.FlagVersionID.VersionID,
+			Delimiter:   s.flagDelimiter.Delimiter,
+			Concurrency: s.FlagConcurrency.Concurrency,
+			DryRun:      s.FlagDryRun.DryRun,
+		},
+	)
+}
+
 type BucketSize struct {
 	ArgPathOptional
 }
@@ -288,6 +649,252 @@ func (s BucketSize) Run(cli CLI, ctrl *controller.Controller) error {
 	)
 }
 
+type BucketEncryption struct {
+	BucketEncryptionGet    BucketEncryptionGet    `cmd:"" name:"get" help:"Get default encryption config."`
+	BucketEncryptionPut    BucketEncryptionPut    `cmd:"" name:"put" help:"Put default encryption config from a file or the --sse flags."`
+	BucketEncryptionDelete BucketEncryptionDelete `cmd:"" name:"rm"  help:"Delete default encryption config."`
+}
+
+type BucketEncryptionGet struct{}
+
+func (s BucketEncryptionGet) Run(cli CLI, ctrl *controller.Controller) error {
+	return ctrl.BucketEncryptionGet(cli.Bucket.BucketArg.BucketName)
+}
+
+type BucketEncryptionPut struct {
+	ArgPathOptional
+	flagsSSE
+}
+
+func (s BucketEncryptionPut) Run(cli CLI, ctrl *controller.Controller) error {
+	return ctrl.BucketEncryptionPut(
+		cli.Bucket.BucketArg.BucketName,
+		controller.BucketEncryptionPutConfig{
+			FilePath: s.ArgPathOptional.Path,
+			SSE:      s.flagsSSE.config(),
+		},
+	)
+}
+
+type BucketEncryptionDelete struct{}
+
+func (s BucketEncryptionDelete) Run(cli CLI, ctrl *controller.Controller) error {
+	return ctrl.BucketEncryptionDelete(cli.Bucket.BucketArg.BucketName)
+}
+
+type Notification struct {
+	BucketNotificationGet    BucketNotificationGet    `cmd:"" name:"get" help:"Get notification config."`
+	BucketNotificationPut    BucketNotificationPut    `cmd:"" name:"put" help:"Put notification config (replaces the existing config)."`
+	BucketNotificationAdd    BucketNotificationAdd    `cmd:"" name:"add" help:"Add a notification to the existing config."`
+	BucketNotificationDelete BucketNotificationDelete `cmd:"" name:"rm"  help:"Delete all notifications."`
+}
+
+type BucketNotificationGet struct{}
+
+func (s BucketNotificationGet) Run(cli CLI, ctrl *controller.Controller) error {
+	return ctrl.BucketNotificationGet(cli.Bucket.BucketArg.BucketName)
+}
+
+type BucketNotificationPut struct {
+	ArgPath
+}
+
+func (s BucketNotificationPut) Run(cli CLI, ctrl *controller.Controller) error {
+	return ctrl.BucketNotificationPut(
+		s.ArgPath.Path,
+		cli.Bucket.BucketArg.BucketName,
+	)
+}
+
+type BucketNotificationAdd struct {
+	TargetARN string   `name:"target-arn" required:"" help:"ARN of the queue, topic or lambda function, e.g. 'arn:minio:sqs::primary:webhook'."`
+	Events    []string `name:"events"     required:"" help:"Events to notify about, e.g. 's3:ObjectCreated:*'."`
+	Prefix    string   `name:"prefix"                 help:"Only notify about keys with this prefix."`
+	Suffix    string   `name:"suffix"                 help:"Only notify about keys with this suffix."`
+	ID        string   `name:"id"                     help:"ID of the notification (default: generated by the server)."`
+}
+
+func (s BucketNotificationAdd) Run(cli CLI, ctrl *controller.Controller) error {
+	return ctrl.BucketNotificationAdd(
+		cli.Bucket.BucketArg.BucketName,
+		controller.BucketNotificationAddConfig{
+			ID:        s.ID,
+			TargetARN: s.TargetARN,
+			Events:    s.Events,
+			Prefix:    s.Prefix,
+			Suffix:    s.Suffix,
+		},
+	)
+}
+
+type BucketNotificationDelete struct{}
+
+func (s BucketNotificationDelete) Run(cli CLI, ctrl *controller.Controller) error {
+	return ctrl.BucketNotificationDelete(cli.Bucket.BucketArg.BucketName)
+}
+
+type Replication struct {
+	BucketReplicationGet    BucketReplicationGet    `cmd:"" name:"get"    help:"Get replication config."`
+	BucketReplicationPut    BucketReplicationPut    `cmd:"" name:"put"    help:"Put replication config (JSON or TOML file)."`
+	BucketReplicationDelete BucketReplicationDelete `cmd:"" name:"rm"     help:"Delete replication config."`
+	ObjectReplicationStatus ObjectReplicationStatus `cmd:"" name:"status" help:"Show the replication status of an object."`
+}
+
+type BucketReplicationGet struct{}
+
+func (s BucketReplicationGet) Run(cli CLI, ctrl *controller.Controller) error {
+	return ctrl.BucketReplicationGet(cli.Bucket.BucketArg.BucketName)
+}
+
+type BucketReplicationPut struct {
+	ArgPath
+}
+
+func (s BucketReplicationPut) Run(cli CLI, ctrl *controller.Controller) error {
+	return ctrl.BucketReplicationPut(
+		s.ArgPath.Path,
+		cli.Bucket.BucketArg.BucketName,
+	)
+}
+
+type BucketReplicationDelete struct{}
+
+func (s BucketReplicationDelete) Run(cli CLI, ctrl *controller.Controller) error {
+	return ctrl.BucketReplicationDelete(cli.Bucket.BucketArg.BucketName)
+}
+
+type ObjectReplicationStatus struct {
+	ArgObject
+	FlagVersionID
+}
+
+func (s ObjectReplicationStatus) Run(cli CLI, ctrl *controller.Controller) error {
+	return ctrl.ObjectReplicationStatus(
+		cli.Bucket.BucketArg.BucketName,
+		s.ArgObject.Object,
+		s.FlagVersionID.VersionID,
+	)
+}
+
+type Website struct {
+	BucketWebsiteGet    BucketWebsiteGet    `cmd:"" name:"get" help:"Get website config."`
+	BucketWebsitePut    BucketWebsitePut    `cmd:"" name:"put" help:"Put website config from a file or the flags."`
+	BucketWebsiteDelete BucketWebsiteDelete `cmd:"" name:"rm"  help:"Delete website config."`
+}
+
+type BucketWebsiteGet struct{}
+
+func (s BucketWebsiteGet) Run(cli CLI, ctrl *controller.Controller) error {
+	return ctrl.BucketWebsiteGet(cli.Bucket.BucketArg.BucketName)
+}
+
+type BucketWebsitePut struct {
+	ArgPathOptional
+	Index         string   `name:"index"           help:"Index document suffix, e.g. 'index.html'."`
+	Error         string   `name:"error"           help:"Error document key, e.g. '404.html'."`
+	Redirect      []string `name:"redirect"        sep:"none" help:"Redirect keys with a prefix, format: 'old-prefix=new-prefix', can be repeated."`
+	RedirectAllTo string   `name:"redirect-all-to" help:"Redirect all requests to the given host, e.g. 'https://example.com'."`
+}
+
+func (s BucketWebsitePut) Run(cli CLI, ctrl *controller.Controller) error {
+	return ctrl.BucketWebsitePut(
+		cli.Bucket.BucketArg.BucketName,
+		controller.BucketWebsitePutConfig{
+			FilePath:      s.ArgPathOptional.Path,
+			IndexDocument: s.Index,
+			ErrorDocument: s.Error,
+			RedirectAllTo: s.RedirectAllTo,
+			Redirects:     s.Redirect,
+		},
+	)
+}
+
+type BucketWebsiteDelete struct{}
+
+func (s BucketWebsiteDelete) Run(cli CLI, ctrl *controller.Controller) error {
+	return ctrl.BucketWebsiteDelete(cli.Bucket.BucketArg.BucketName)
+}
+
+type BucketLogging struct {
+	BucketLoggingGet BucketLoggingGet `cmd:"" name:"get" help:"Get access logging config."`
+	BucketLoggingPut BucketLoggingPut `cmd:"" name:"put" help:"Put access logging config (an empty config disables logging)."`
+}
+
+type BucketLoggingGet struct{}
+
+func (s BucketLoggingGet) Run(cli CLI, ctrl *controller.Controller) error {
+	return ctrl.BucketLoggingGet(cli.Bucket.BucketArg.BucketName)
+}
+
+type BucketLoggingPut struct {
+	ArgPath
+}
+
+func (s BucketLoggingPut) Run(cli CLI, ctrl *controller.Controller) error {
+	return ctrl.BucketLoggingPut(
+		s.ArgPath.Path,
+		cli.Bucket.BucketArg.BucketName,
+	)
+}
+
+type PublicAccess struct {
+	PublicAccessBlockGet    PublicAccessBlockGet    `cmd:"" name:"get" help:"Get public access block config."`
+	PublicAccessBlockPut    PublicAccessBlockPut    `cmd:"" name:"put" help:"Put public access block config."`
+	PublicAccessBlockDelete PublicAccessBlockDelete `cmd:"" name:"rm"  help:"Delete public access block config."`
+}
+
+type PublicAccessBlockGet struct{}
+
+func (s PublicAccessBlockGet) Run(cli CLI, ctrl *controller.Controller) error {
+	return ctrl.BucketPublicAccessBlockGet(cli.Bucket.BucketArg.BucketName)
+}
+
+type PublicAccessBlockPut struct {
+	ArgPath
+}
+
+func (s PublicAccessBlockPut) Run(cli CLI, ctrl *controller.Controller) error {
+	return ctrl.BucketPublicAccessBlockPut(
+		s.ArgPath.Path,
+		cli.Bucket.BucketArg.BucketName,
+	)
+}
+
+type PublicAccessBlockDelete struct{}
+
+func (s PublicAccessBlockDelete) Run(cli CLI, ctrl *controller.Controller) error {
+	return ctrl.BucketPublicAccessBlockDelete(cli.Bucket.BucketArg.BucketName)
+}
+
+type BucketOwnership struct {
+	BucketOwnershipGet    BucketOwnershipGet    `cmd:"" name:"get" help:"Get object ownership config."`
+	BucketOwnershipPut    BucketOwnershipPut    `cmd:"" name:"put" help:"Put object ownership config."`
+	BucketOwnershipDelete BucketOwnershipDelete `cmd:"" name:"rm"  help:"Delete object ownership config."`
+}
+
+type BucketOwnershipGet struct{}
+
+func (s BucketOwnershipGet) Run(cli CLI, ctrl *controller.Controller) error {
+	return ctrl.BucketOwnershipGet(cli.Bucket.BucketArg.BucketName)
+}
+
+type BucketOwnershipPut struct {
+	Ownership string `arg:"" name:"ownership" help:"'BucketOwnerEnforced', 'BucketOwnerPreferred' or 'ObjectWriter'."`
+}
+
+func (s BucketOwnershipPut) Run(cli CLI, ctrl *controller.Controller) error {
+	return ctrl.BucketOwnershipPut(
+		cli.Bucket.BucketArg.BucketName,
+		s.Ownership,
+	)
+}
+
+type BucketOwnershipDelete struct{}
+
+func (s BucketOwnershipDelete) Run(cli CLI, ctrl *controller.Controller) error {
+	return ctrl.BucketOwnershipDelete(cli.Bucket.BucketArg.BucketName)
+}
+
 type BucketVersioning struct {
 	BucketVersioningGet BucketVersioningGet `cmd:"" name:"get" help:"Get bucket versioning config."`
 	BucketVersioningPut BucketVersioningPut `cmd:"" name:"put" help:"Put bucket versioning config."`
@@ -369,6 +976,30 @@ func (s ObjectLockPut) Run(cli CLI, ctrl *controller.Controller) error {
 	)
 }
 
+type BucketACL struct {
+	BucketACLGet BucketACLGet `cmd:"" name:"get" help:"Get bucket ACL."`
+	BucketACLPut BucketACLPut `cmd:"" name:"put" help:"Put bucket ACL."`
+}
+
+type BucketACLGet struct{}
+
+func (s BucketACLGet) Run(cli CLI, ctrl *controller.Controller) error {
+	return ctrl.BucketACLGet(
+		cli.Bucket.BucketArg.BucketName,
+	)
+}
+
+type BucketACLPut struct {
+	flagsACL
+}
+
+func (s BucketACLPut) Run(cli CLI, ctrl *controller.Controller) error {
+	return ctrl.BucketACLPut(
+		cli.Bucket.BucketArg.BucketName,
+		s.flagsACL.config(),
+	)
+}
+
 type BucketCors struct {
 	BucketCorsGet    BucketCorsGet    `cmd:"" name:"get" help:"Get CORS policy."`
 	BucketCorsPut    BucketCorsPut    `cmd:"" name:"put" help:"Put CORS policy."`
@@ -525,20 +1156,28 @@ type ObjectDelete struct {
 	FlagDryRun
 	FlagForce
 	FlagVersionID
+	FlagBypassGovernance
 	flagDelimiter
+	Trash *bool `name:"trash" negatable:"" help:"Move objects to the '.trash/' prefix instead of deleting them (default from profile)."`
 }
 
-func (s ObjectDelete) Run(cli CLI, ctrl *controller.Controller) error {
+func (s ObjectDelete) Run(cli CLI, ctrl *controller.Controller, config controller.Config) error {
+	trash := config.Profiles[cli.Profile].Trash
+	if s.Trash != nil {
+		trash = *s.Trash
+	}
+
 	return ctrl.ObjectDelete(
 		cli.Bucket.BucketArg.ObjectDelete.Object,
 		controller.ObjectDeleteConfig{
-			Bucket:      cli.Bucket.BucketArg.BucketName,
-			Delimiter:   s.flagDelimiter.Delimiter,
-			Force:       s.FlagForce.Force,
-			Concurrency: s.FlagConcurrency.Concurrency,
-			DryRun:      s.FlagDryRun.DryRun,
-			VersionID:   s.FlagVersionID.VersionID,
-			// BypassGovernance: ,
+			Bucket:           cli.Bucket.BucketArg.BucketName,
+			Delimiter:        s.flagDelimiter.Delimiter,
+			Force:            s.FlagForce.Force,
+			Concurrency:      s.FlagConcurrency.Concurrency,
+			DryRun:           s.FlagDryRun.DryRun,
+			VersionID:        s.FlagVersionID.VersionID,
+			Trash:            trash,
+			BypassGovernance: s.FlagBypassGovernance.BypassGovernance,
 		})
 
 }
@@ -553,7 +1192,11 @@ type ObjectPut struct {
 	FlagConcurrency
 	FlagDryRun
 	flagsSSEC
+	flagsSSE
 	flagExpires
+	flagTags
+	flagStorageClass
+	flagsContent
 }
 
 func (s ObjectPut) Run(cli CLI, ctrl *controller.Controller) error {
@@ -561,15 +1204,24 @@ func (s ObjectPut) Run(cli CLI, ctrl *controller.Controller) error {
 		cli.Bucket.BucketArg.ObjectPut.Filepath,
 		cli.Bucket.BucketArg.ObjectPut.Destinaton,
 		controller.ObjectPutConfig{
-			Bucket:            cli.Bucket.BucketArg.BucketName,
-			Concurrency:       cli.Bucket.BucketArg.ObjectPut.FlagConcurrency.Concurrency,
-			DryRun:            cli.Bucket.BucketArg.ObjectPut.FlagDryRun.DryRun,
-			SSEC:              util.NewSSEC(s.flagsSSEC.Algo, s.flagsSSEC.Key),
-			PartSize:          s.FlagPartSize,
-			MaxUploadParts:    s.FlagMaxUploadParts,
-			LeavePartsOnError: s.FlagLeavePartsOnError,
-			ACL:               s.FlagACL,
-			Expires:           s.flagExpires.Expires,
+			Bucket:             cli.Bucket.BucketArg.BucketName,
+			Concurrency:        cli.Bucket.BucketArg.ObjectPut.FlagConcurrency.Concurrency,
+			DryRun:             cli.Bucket.BucketArg.ObjectPut.FlagDryRun.DryRun,
+			SSEC:               util.NewSSEC(s.flagsSSEC.Algo, s.flagsSSEC.Key),
+			SSE:                s.flagsSSE.config(),
+			PartSize:           s.FlagPartSize,
+			MaxUploadParts:     s.FlagMaxUploadParts,
+			LeavePartsOnError:  s.FlagLeavePartsOnError,
+			ACL:                s.FlagACL,
+			Expires:            s.flagExpires.Expires,
+			Tags:               s.flagTags.Tags,
+			StorageClass:       s.flagStorageClass.StorageClass,
+			ContentType:        s.flagsContent.ContentType,
+			CacheControl:       s.flagsContent.CacheControl,
+			ContentDisposition: s.flagsContent.ContentDisposition,
+			ContentEncoding:    s.flagsContent.ContentEncoding,
+			ContentLanguage:    s.flagsContent.ContentLanguage,
+			Metadata:           s.flagsContent.Metadata,
 		},
 	)
 }
@@ -582,7 +1234,10 @@ type ObjectPutRand struct {
 	FlagConcurrency
 	FlagDryRun
 	flagsSSEC
+	flagsSSE
 	flagExpires
+	flagTags
+	flagsContent
 	flagSize
 	flagPath
 	flagCount
@@ -599,33 +1254,74 @@ func (s ObjectPutRand) Run(cli CLI, ctrl *controller.Controller) error {
 		size,
 		s.flagCount.Count,
 		controller.ObjectPutConfig{
-			Bucket:            cli.Bucket.BucketArg.BucketName,
-			Concurrency:       s.FlagConcurrency.Concurrency,
-			DryRun:            s.FlagDryRun.DryRun,
-			SSEC:              util.NewSSEC(s.flagsSSEC.Algo, s.flagsSSEC.Key),
-			PartSize:          s.FlagPartSize,
-			MaxUploadParts:    s.FlagMaxUploadParts,
-			LeavePartsOnError: s.FlagLeavePartsOnError,
-			ACL:               s.FlagACL,
-			Expires:           s.flagExpires.Expires,
+			Bucket:             cli.Bucket.BucketArg.BucketName,
+			Concurrency:        s.FlagConcurrency.Concurrency,
+			DryRun:             s.FlagDryRun.DryRun,
+			SSEC:               util.NewSSEC(s.flagsSSEC.Algo, s.flagsSSEC.Key),
+			SSE:                s.flagsSSE.config(),
+			PartSize:           s.FlagPartSize,
+			MaxUploadParts:     s.FlagMaxUploadParts,
+			LeavePartsOnError:  s.FlagLeavePartsOnError,
+			ACL:                s.FlagACL,
+			Expires:            s.flagExpires.Expires,
+			Tags:               s.flagTags.Tags,
+			ContentType:        s.flagsContent.ContentType,
+			CacheControl:       s.flagsContent.CacheControl,
+			ContentDisposition: s.flagsContent.ContentDisposition,
+			ContentEncoding:    s.flagsContent.ContentEncoding,
+			ContentLanguage:    s.flagsContent.ContentLanguage,
+			Metadata:           s.flagsContent.Metadata,
 		},
 	)
 }
 
 type ObjectCopy struct {
-	SrcObject string `arg:"" name:"src-object"`
-	DstBucket string `arg:"" name:"dst-bucket"`
-	DstObject string `arg:"" name:"dst-object"`
+	SrcObject         string   `arg:"" name:"src-object"`
+	DstBucket         string   `arg:"" name:"dst-bucket"`
+	DstObject         string   `arg:"" name:"dst-object"`
+	SrcVersionID      string   `name:"src-version-id"     help:"Version ID of the source object."`
+	MetadataDirective string   `name:"metadata-directive" help:"'COPY' or 'REPLACE' the metadata (default: REPLACE when content or metadata flags are set)."`
+	TaggingDirective  string   `name:"tagging-directive"  help:"'COPY' or 'REPLACE' the tags (default: REPLACE when tags are set)."`
+	FlagPartSize      int64    `name:"part-size"          help:"Part size for multipart copies, objects over 5 GiB are always copied in parts."`
+	Include           []string `name:"include"            sep:"none" help:"Only copy keys below the source prefix matching the glob pattern, can be repeated."`
+	Exclude           []string `name:"exclude"            sep:"none" help:"Skip keys below the source prefix matching the glob pattern, can be repeated."`
+	FlagConcurrency
+	FlagDryRun
+	flagDelimiter
 	flagsSSEC
+	flagsSrcSSEC
+	flagsSSE
+	flagsContent
+	flagTags
+	flagStorageClass
 }
 
 func (s ObjectCopy) Run(cli CLI, ctrl *controller.Controller) error {
 	return ctrl.ObjectCopy(controller.ObjectCopyConfig{
-		SrcBucket: cli.Bucket.BucketArg.BucketName,
-		SrcKey:    cli.Bucket.BucketArg.ObjectCopy.SrcObject,
-		DstBucket: cli.Bucket.BucketArg.ObjectCopy.DstBucket,
-		DstKey:    cli.Bucket.BucketArg.ObjectCopy.DstObject,
-		SSEC:      util.NewSSEC(s.flagsSSEC.Algo, s.flagsSSEC.Key),
+		SrcBucket:          cli.Bucket.BucketArg.BucketName,
+		SrcKey:             cli.Bucket.BucketArg.ObjectCopy.SrcObject,
+		SrcVersionID:       s.SrcVersionID,
+		DstBucket:          cli.Bucket.BucketArg.ObjectCopy.DstBucket,
+		DstKey:             cli.Bucket.BucketArg.ObjectCopy.DstObject,
+		SrcSSEC:            util.NewSSEC(s.flagsSrcSSEC.Algo, s.flagsSrcSSEC.Key),
+		SSEC:               util.NewSSEC(s.flagsSSEC.Algo, s.flagsSSEC.Key),
+		SSE:                s.flagsSSE.config(),
+		Tags:               s.flagTags.Tags,
+		PartSize:           s.FlagPartSize,
+		Concurrency:        s.FlagConcurrency.Concurrency,
+		Delimiter:          s.flagDelimiter.Delimiter,
+		Include:            s.Include,
+		Exclude:            s.Exclude,
+		DryRun:             s.FlagDryRun.DryRun,
+		MetadataDirective:  s.MetadataDirective,
+		TaggingDirective:   s.TaggingDirective,
+		ContentType:        s.flagsContent.ContentType,
+		CacheControl:       s.flagsContent.CacheControl,
+		ContentDisposition: s.flagsContent.ContentDisposition,
+		ContentEncoding:    s.flagsContent.ContentEncoding,
+		ContentLanguage:    s.flagsContent.ContentLanguage,
+		Metadata:           s.flagsContent.Metadata,
+		StorageClass:       s.flagStorageClass.StorageClass,
 	})
 }
 
@@ -640,10 +1336,15 @@ func (s BucketCreate) Run(cli CLI, ctrl *controller.Controller) error {
 	)
 }
 
-type BucketHead struct{}
+type BucketHead struct {
+	Summary bool `name:"summary" short:"s" help:"Summarize all bucket settings (versioning, encryption, ownership, ...)."`
+}
 
 func (s BucketHead) Run(cli CLI, ctrl *controller.Controller) error {
-	return ctrl.BucketHead(cli.Bucket.BucketArg.BucketName)
+	return ctrl.BucketHead(
+		cli.Bucket.BucketArg.BucketName,
+		s.Summary,
+	)
 }
 
 type BucketRemove struct {
@@ -674,7 +1375,9 @@ func (s ObjectList) Run(cli CLI, ctrl *controller.Controller) error {
 }
 
 type BucketTag struct {
-	BucketTagGet BucketTagGet `cmd:"" name:"get" help:"Get bucket tag."`
+	BucketTagGet    BucketTagGet    `cmd:"" name:"get"                help:"Get bucket tag."`
+	BucketTagPut    BucketTagPut    `cmd:"" name:"put" aliases:"set"  help:"Put bucket tags (replaces all tags unless --merge is set)."`
+	BucketTagRemove BucketTagRemove `cmd:"" name:"rm"                 help:"Delete the given or all bucket tags."`
 }
 
 type BucketTagGet struct{}
@@ -683,11 +1386,42 @@ func (s BucketTagGet) Run(cli CLI, ctrl *controller.Controller) error {
 	return ctrl.BucketTagging(cli.Bucket.BucketArg.BucketName)
 }
 
+type BucketTagPut struct {
+	Tags  []string `arg:"" name:"key=value" optional:""`
+	File  string   `name:"file"  help:"JSON file with the tag set."`
+	Merge bool     `name:"merge" help:"Only add or update the given tags and keep all other tags."`
+}
+
+func (s BucketTagPut) Run(cli CLI, ctrl *controller.Controller) error {
+	return ctrl.BucketTaggingPut(
+		cli.Bucket.BucketArg.BucketName,
+		controller.BucketTaggingPutConfig{
+			Tags:     s.Tags,
+			FilePath: s.File,
+			Merge:    s.Merge,
+		},
+	)
+}
+
+type BucketTagRemove struct {
+	Keys []string `arg:"" name:"key" optional:""`
+}
+
+func (s BucketTagRemove) Run(cli CLI, ctrl *controller.Controller) error {
+	return ctrl.BucketTaggingDelete(
+		cli.Bucket.BucketArg.BucketName,
+		s.Keys,
+	)
+}
+
 type Multipart struct {
-	MultipartRemove MultipartRemove `cmd:"" name:"rm"     help:"Delete multipart upload."`
-	MultipartList   MultipartList   `cmd:"" name:"ls"     help:"List multipart uploads."`
-	MultipartCreate MultipartCreate `cmd:"" name:"create" help:"Create multipart upload."`
-	MultipartParts  MultipartParts  `cmd:"" name:"parts"  help:"Manage parts."`
+	MultipartRemove   MultipartRemove   `cmd:"" name:"rm"               help:"Delete multipart upload."`
+	MultipartList     MultipartList     `cmd:"" name:"ls"               help:"List multipart uploads."`
+	MultipartCreate   MultipartCreate   `cmd:"" name:"create"           help:"Create multipart upload."`
+	MultipartComplete MultipartComplete `cmd:"" name:"complete"         help:"Complete multipart upload."`
+	MultipartParts    MultipartParts    `cmd:"" name:"parts"            help:"Manage parts."`
+	PartUpload        PartUpload        `cmd:"" name:"upload-part"      help:"Upload a part from a file or stdin ('-')."`
+	PartUploadCopy    PartUploadCopy    `cmd:"" name:"upload-part-copy" help:"Copy an object or a range of it as a part."`
 }
 
 type MultipartCreate struct {
@@ -731,6 +1465,63 @@ func (s MultipartRemove) Run(cli CLI, ctrl *controller.Controller) error {
 
 }
 
+type MultipartComplete struct {
+	ArgObject
+	ArgUploadID
+	File string `name:"file" help:"JSON file with the parts to complete (default: all uploaded parts)."`
+}
+
+func (s MultipartComplete) Run(cli CLI, ctrl *controller.Controller) error {
+	return ctrl.MultipartUploadComplete(
+		cli.Bucket.BucketArg.BucketName,
+		s.ArgObject.Object,
+		s.ArgUploadID.UploadID,
+		s.File,
+	)
+}
+
+type PartUpload struct {
+	ArgObject
+	ArgUploadID
+	ArgPartNumber
+	ArgPath
+}
+
+func (s PartUpload) Run(cli CLI, ctrl *controller.Controller) error {
+	return ctrl.PartUpload(
+		cli.Bucket.BucketArg.BucketName,
+		s.ArgObject.Object,
+		s.ArgUploadID.UploadID,
+		s.ArgPartNumber.PartNumber,
+		s.ArgPath.Path,
+	)
+}
+
+type PartUploadCopy struct {
+	ArgObject
+	ArgUploadID
+	ArgPartNumber
+	SrcObject    string `arg:"" name:"src-object"`
+	SrcBucket    string `name:"src-bucket"     help:"Source bucket (default: same bucket)."`
+	SrcVersionID string `name:"src-version-id" help:"Version ID of the source object."`
+	Range        string `name:"range"          help:"Byte range of the source object, e.g. '0-5242879'."`
+}
+
+func (s PartUploadCopy) Run(cli CLI, ctrl *controller.Controller) error {
+	return ctrl.PartUploadCopy(
+		cli.Bucket.BucketArg.BucketName,
+		s.ArgObject.Object,
+		s.ArgUploadID.UploadID,
+		s.ArgPartNumber.PartNumber,
+		controller.PartUploadCopyConfig{
+			SrcBucket:    s.SrcBucket,
+			SrcKey:       s.SrcObject,
+			SrcVersionID: s.SrcVersionID,
+			Range:        s.Range,
+		},
+	)
+}
+
 type MultipartParts struct {
 	PartsList PartsList `cmd:"" name:"ls" help:"List parts."`
 }
@@ -749,3 +1540,47 @@ func (s PartsList) Run(cli CLI, ctrl *controller.Controller) error {
 		cli.Bucket.BucketArg.Multiparts.MultipartParts.PartsList.AsJson,
 	)
 }
+
+type Trash struct {
+	TrashList    TrashList    `cmd:"" name:"ls"      help:"List trashed objects."`
+	TrashRestore TrashRestore `cmd:"" name:"restore" help:"Restore objects by key or by deletion timestamp."`
+	TrashPurge   TrashPurge   `cmd:"" name:"purge"   help:"Permanently delete trashed objects."`
+}
+
+type TrashList struct{}
+
+func (s TrashList) Run(cli CLI, ctrl *controller.Controller) error {
+	return ctrl.TrashList(cli.Bucket.BucketArg.BucketName)
+}
+
+type TrashRestore struct {
+	Target string `arg:"" name:"key|timestamp"`
+	FlagConcurrency
+	FlagDryRun
+}
+
+func (s TrashRestore) Run(cli CLI, ctrl *controller.Controller) error {
+	return ctrl.TrashRestore(
+		s.Target,
+		controller.TrashRestoreConfig{
+			Bucket:      cli.Bucket.BucketArg.BucketName,
+			Concurrency: s.FlagConcurrency.Concurrency,
+			DryRun:      s.FlagDryRun.DryRun,
+		},
+	)
+}
+
+type TrashPurge struct {
+	OlderThan time.Duration `name:"older-than" help:"Only purge objects deleted before the given duration, e.g. '720h'."`
+	FlagConcurrency
+	FlagDryRun
+}
+
+func (s TrashPurge) Run(cli CLI, ctrl *controller.Controller) error {
+	return ctrl.TrashPurge(controller.TrashPurgeConfig{
+		Bucket:      cli.Bucket.BucketArg.BucketName,
+		OlderThan:   s.OlderThan,
+		Concurrency: s.FlagConcurrency.Concurrency,
+		DryRun:      s.FlagDryRun.DryRun,
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"maps"
+	"os"
 	"slices"
 
 	"github.com/alecthomas/kong"
@@ -58,26 +59,53 @@ func Exec(ctx context.Context, outWriter, errWriter io.Writer, buildInfo util.Bu
 	util.SetIfNotZero(&profile.SNI, cli.SNI)
 	util.SetIfNotZero(&profile.Network, cli.Network)
 	util.SetIfNotZero(&profile.Bandwidth, cli.Bandwidth)
+	if cli.Bandwidth != "" {
+		// the flag overrides the upload and download limits of the profile
+		profile.UploadBandwidth = ""
+		profile.DownloadBandwidth = ""
+	}
+	util.SetIfNotZero(&profile.UploadBandwidth, cli.UploadBW)
+	util.SetIfNotZero(&profile.DownloadBandwidth, cli.DownloadBW)
+	util.SetIfNotZero(&profile.CAFile, cli.CAFile)
+	util.SetIfNotZero(&profile.ClientCertFile, cli.ClientCert)
+	util.SetIfNotZero(&profile.ClientKeyFile, cli.ClientKey)
+	util.SetIfNotZero(&profile.TLSMinVersion, cli.TLSMin)
+	util.SetIfNotZero(&profile.Proxy, cli.Proxy)
+	util.SetIfNotZero(&profile.NoProxy, cli.NoProxy)
+	util.SetIfNotZero(&profile.MaxRetries, cli.MaxRetries)
+	util.SetIfNotZero(&profile.RetryMode, cli.RetryMode)
+	util.SetIfNotZero(&profile.MaxBackoff, cli.MaxBackoff)
+	util.SetIfNotZero(&profile.ConnectTimeout, cli.ConnectTimeout)
+	util.SetIfNotZero(&profile.RequestTimeout, cli.RequestTimeout)
+	util.SetIfNotZero(&profile.IdleReadTimeout, cli.IdleReadTimeout)
+	util.SetIfNotZero(&profile.RequestsPerSecond, cli.RequestsPerSecond)
+	util.SetIfNotZero(&profile.RequestBurst, cli.RequestBurst)
 
 	dryRun := isFlagSet(kctx.Selected().Flags, "dry-run")
 
 	ctrl, err := controller.New(
 		ctx,
 		controller.ControllerConfig{
-			OutWriter: outWriter,
-			ErrWriter: errWriter,
-			Profile:   profile,
-			Verbosity: cli.Verbosity,
-			Headers:   cli.Headers,
-			Params:    cli.Params,
-			DryRun:    dryRun,
-			BuildInfo: buildInfo,
+			OutWriter:   outWriter,
+			ErrWriter:   errWriter,
+			Profile:     profile,
+			ProfileName: cli.Profile,
+			Verbosity:   cli.Verbosity,
+			Headers:     cli.Headers,
+			Params:      cli.Params,
+			DryRun:      dryRun,
+			BuildInfo:   buildInfo,
+			InReader:    os.Stdin,
+			Interactive: util.IsTerminal(os.Stdin),
+			AssumeYes:   cli.Yes,
+			MimeTypes:   config.Mime,
 		})
 	if err != nil {
 		return err
 	}
 
 	err = kctx.Run(cli, ctrl, config)
+	ctrl.PrintStats()
 	if err != nil {
 		return err
 	}
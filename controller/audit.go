@@ -0,0 +1,223 @@
+package controller
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"time"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go"
+	smithymiddleware "github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"github.com/dustin/go-humanize"
+)
+
+// AuditLogDisabled can be set as audit_log to disable the audit log.
+const AuditLogDisabled = "off"
+
+func DefaultAuditLogPath() (string, error) {
+	stateDir := os.Getenv("XDG_STATE_HOME")
+	if stateDir == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		stateDir = filepath.Join(homeDir, ".local", "state")
+	}
+
+	return filepath.Join(stateDir, "sss", "audit.jsonl"), nil
+}
+
+type AuditEntry struct {
+	Time      time.Time `json:"time"`
+	Profile   string    `json:"profile"`
+	Endpoint  string    `json:"endpoint"`
+	Operation string    `json:"operation"`
+	Bucket    string    `json:"bucket,omitempty"`
+	Key       string    `json:"key,omitempty"`
+	VersionID string    `json:"version_id,omitempty"`
+	Bytes     int64     `json:"bytes"`
+	Status    int       `json:"status,omitempty"`
+	Result    string    `json:"result"`
+	RequestID string    `json:"request_id,omitempty"`
+
+	method string
+}
+
+type auditEntryKey struct{}
+
+// AuditMiddleware appends one JSON line per mutating request, and per request
+// denied by the permission policy, to the audit log.
+type AuditMiddleware struct {
+	Path      string
+	Profile   string
+	Endpoint  string
+	ErrWriter io.Writer
+
+	mu sync.Mutex
+}
+
+func (m *AuditMiddleware) ID() string {
+	return "AuditLog"
+}
+
+func (m *AuditMiddleware) HandleInitialize(
+	ctx context.Context,
+	in smithymiddleware.InitializeInput,
+	next smithymiddleware.InitializeHandler,
+) (
+	smithymiddleware.InitializeOutput,
+	smithymiddleware.Metadata,
+	error,
+) {
+	entry := &AuditEntry{
+		Profile:   m.Profile,
+		Endpoint:  m.Endpoint,
+		Operation: smithymiddleware.GetOperationName(ctx),
+	}
+
+	if v := reflect.ValueOf(in.Parameters); v.Kind() == reflect.Pointer && !v.IsNil() && v.Elem().Kind() == reflect.Struct {
+		entry.Bucket, _ = stringField(v.Elem(), "Bucket")
+		entry.Key, _ = stringField(v.Elem(), "Key")
+		entry.VersionID, _ = stringField(v.Elem(), "VersionId")
+	}
+
+	ctx = smithymiddleware.WithStackValue(ctx, auditEntryKey{}, entry)
+
+	out, metadata, err := next.HandleInitialize(ctx, in)
+
+	denied := errors.Is(err, ErrPermissionDenied)
+
+	switch entry.method {
+	case "", http.MethodHead, http.MethodGet, http.MethodOptions, http.MethodTrace:
+		// not sent or not mutating
+		if !denied {
+			return out, metadata, err
+		}
+	}
+
+	entry.Time = time.Now().UTC()
+	entry.RequestID, _ = awsmiddleware.GetRequestIDMetadata(metadata)
+	if resp, ok := awsmiddleware.GetRawResponse(metadata).(*smithyhttp.Response); ok {
+		entry.Status = resp.StatusCode
+	}
+
+	var apiErr smithy.APIError
+	switch {
+	case err == nil:
+		entry.Result = "OK"
+	case denied:
+		entry.Result = "Denied"
+	case errors.As(err, &apiErr):
+		entry.Result = apiErr.ErrorCode()
+	default:
+		entry.Result = err.Error()
+	}
+
+	if writeErr := m.write(entry); writeErr != nil {
+		fmt.Fprintf(m.ErrWriter, "failed to write audit log: %v\n", writeErr)
+	}
+
+	return out, metadata, err
+}
+
+func (m *AuditMiddleware) write(entry *AuditEntry) error {
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(m.Path), 0o700); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(m.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.Write(append(b, '\n'))
+	return err
+}
+
+// auditRequestMiddleware records the HTTP method and the body size of the sent request.
+type auditRequestMiddleware struct{}
+
+func (m *auditRequestMiddleware) ID() string {
+	return "AuditLogRequest"
+}
+
+func (m *auditRequestMiddleware) HandleFinalize(
+	ctx context.Context,
+	in smithymiddleware.FinalizeInput,
+	next smithymiddleware.FinalizeHandler,
+) (
+	smithymiddleware.FinalizeOutput,
+	smithymiddleware.Metadata,
+	error,
+) {
+	entry, ok := smithymiddleware.GetStackValue(ctx, auditEntryKey{}).(*AuditEntry)
+	if req, isHTTP := in.Request.(*smithyhttp.Request); ok && isHTTP {
+		entry.method = req.Method
+		entry.Bytes = max(req.ContentLength, 0)
+	}
+
+	return next.HandleFinalize(ctx, in)
+}
+
+func (c *Controller) AuditList(asJson bool) error {
+	if c.auditPath == "" {
+		return errors.New("audit log is disabled")
+	}
+
+	f, err := os.Open(c.auditPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if asJson {
+			fmt.Fprintln(c.OutWriter, scanner.Text())
+			continue
+		}
+
+		var entry AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return fmt.Errorf("failed to parse audit log: %w", err)
+		}
+
+		target := entry.Bucket
+		if entry.Key != "" {
+			target += "/" + entry.Key
+		}
+		if entry.VersionID != "" {
+			target += " (" + entry.VersionID + ")"
+		}
+
+		fmt.Fprintf(c.OutWriter, "%s  %-10s  %-24s  %8s  %s  %s\n",
+			entry.Time.Local().Format(time.DateTime),
+			entry.Profile,
+			entry.Operation,
+			humanize.IBytes(uint64(entry.Bytes)),
+			target,
+			entry.Result,
+		)
+	}
+
+	return scanner.Err()
+}
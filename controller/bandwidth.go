@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/sj14/sss/util/ratelimiter"
+	"golang.org/x/time/rate"
+)
+
+// add a small burst, otherwise it might fail
+const bandwidthBurst = 64 * 1024
+
+// bandwidthLimiters creates the upload and download limiters of the profile.
+// With a schedule, the limits are adjusted while transfers are running.
+// A scheduled limit applies to both directions.
+func bandwidthLimiters(ctx context.Context, profile Profile) (upload, download *rate.Limiter, err error) {
+	uploadLimit, err := parseBandwidth(profile.UploadBandwidth, profile.Bandwidth)
+	if err != nil {
+		return nil, nil, err
+	}
+	downloadLimit, err := parseBandwidth(profile.DownloadBandwidth, profile.Bandwidth)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	schedule, err := ratelimiter.ParseSchedule(profile.BandwidthSchedule)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if uploadLimit != rate.Inf || len(schedule) > 0 {
+		upload = rate.NewLimiter(uploadLimit, bandwidthBurst)
+	}
+	if downloadLimit != rate.Inf || len(schedule) > 0 {
+		download = rate.NewLimiter(downloadLimit, bandwidthBurst)
+	}
+
+	if len(schedule) == 0 {
+		return upload, download, nil
+	}
+
+	apply := func(now time.Time) {
+		if limit, ok := schedule.Limit(now); ok {
+			upload.SetLimit(limit)
+			download.SetLimit(limit)
+			return
+		}
+		upload.SetLimit(uploadLimit)
+		download.SetLimit(downloadLimit)
+	}
+
+	apply(time.Now())
+
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				apply(now)
+			}
+		}
+	}()
+
+	return upload, download, nil
+}
+
+// parseBandwidth parses the first non-empty value, without any value there is no limit.
+func parseBandwidth(values ...string) (rate.Limit, error) {
+	for _, v := range values {
+		if v != "" {
+			return ratelimiter.ParseLimit(v)
+		}
+	}
+	return rate.Inf, nil
+}
@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+func (c *Controller) BucketACLGet(bucket string) error {
+	resp, err := c.client.GetBucketAcl(c.ctx, &s3.GetBucketAclInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		return err
+	}
+
+	b, err := json.MarshalIndent(types.AccessControlPolicy{
+		Owner:  resp.Owner,
+		Grants: resp.Grants,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(b))
+
+	return nil
+}
+
+func (c *Controller) BucketACLPut(bucket string, cfg ACLPutConfig) error {
+	policy, err := cfg.policy()
+	if err != nil {
+		return err
+	}
+
+	_, err = c.client.PutBucketAcl(c.ctx, &s3.PutBucketAclInput{
+		Bucket:              aws.String(bucket),
+		ACL:                 types.BucketCannedACL(cfg.Canned),
+		AccessControlPolicy: policy,
+		GrantRead:           grantHeader(cfg.GrantRead),
+		GrantFullControl:    grantHeader(cfg.GrantFullControl),
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
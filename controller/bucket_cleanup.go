@@ -23,6 +23,22 @@ func (c *Controller) BucketCleanup(cfg BucketCleanupConfig) error {
 		return fmt.Errorf("at least one of --all-object-versions or --all-multiparts needs to be set")
 	}
 
+	if !cfg.DryRun {
+		err := c.confirmDelete(cfg.Bucket, "", true, func(preview string) string {
+			action := fmt.Sprintf("Cleaning up bucket %q", cfg.Bucket)
+			if cfg.ObjectVersion {
+				action += fmt.Sprintf(", deleting %s", preview)
+			}
+			if cfg.Multiparts {
+				action += ", aborting all multipart uploads"
+			}
+			return action + "."
+		})
+		if err != nil {
+			return err
+		}
+	}
+
 	if cfg.ObjectVersion {
 		fmt.Fprintln(c.OutWriter, "> deleting all objects <")
 
@@ -40,6 +56,7 @@ func (c *Controller) BucketCleanup(cfg BucketCleanupConfig) error {
 					DryRun:           cfg.DryRun,
 					BypassGovernance: cfg.BypassGovernance,
 					VersionID:        *version.VersionId,
+					Confirmed:        true,
 				})
 				if err != nil {
 					return err
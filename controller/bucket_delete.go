@@ -23,6 +23,14 @@ func (c *Controller) BucketDelete(bucket string, flagForce bool) error {
 			return fmt.Errorf("bucket not empty, use 'force' flag to delete the bucket")
 		}
 	}
+	if flagForce {
+		err := c.confirmDelete(bucket, "", true, func(preview string) string {
+			return fmt.Sprintf("Deleting bucket %q containing %s.", bucket, preview)
+		})
+		if err != nil {
+			return err
+		}
+	}
 	_, err := c.client.DeleteBucket(c.ctx, &s3.DeleteBucketInput{
 		Bucket: aws.String(bucket),
 	})
@@ -0,0 +1,96 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/sj14/sss/util"
+)
+
+func (c *Controller) BucketEncryptionGet(bucket string) error {
+	resp, err := c.client.GetBucketEncryption(c.ctx, &s3.GetBucketEncryptionInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		return err
+	}
+
+	b, err := json.MarshalIndent(resp.ServerSideEncryptionConfiguration, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(b))
+
+	return nil
+}
+
+type BucketEncryptionPutConfig struct {
+	// FilePath to a JSON file with the server-side encryption configuration.
+	FilePath string
+	SSE      SSEConfig
+}
+
+func (c *Controller) BucketEncryptionPut(bucket string, cfg BucketEncryptionPutConfig) error {
+	var configuration *types.ServerSideEncryptionConfiguration
+
+	switch {
+	case cfg.FilePath != "" && cfg.SSE.Algorithm != "":
+		return errors.New("configuration file and encryption flags can't be combined")
+	case cfg.FilePath != "":
+		lBytes, err := os.ReadFile(cfg.FilePath)
+		if err != nil {
+			return err
+		}
+
+		dec := json.NewDecoder(bytes.NewBuffer(lBytes))
+		dec.DisallowUnknownFields()
+
+		if err := dec.Decode(&configuration); err != nil {
+			return fmt.Errorf("failed to unmarshal configuration file: %w", err)
+		}
+	case cfg.SSE.Algorithm != "":
+		if err := cfg.SSE.validate(); err != nil {
+			return err
+		}
+
+		configuration = &types.ServerSideEncryptionConfiguration{
+			Rules: []types.ServerSideEncryptionRule{{
+				ApplyServerSideEncryptionByDefault: &types.ServerSideEncryptionByDefault{
+					SSEAlgorithm:   types.ServerSideEncryption(cfg.SSE.Algorithm),
+					KMSMasterKeyID: util.NilIfZero(cfg.SSE.KMSKeyID),
+				},
+				BucketKeyEnabled: util.NilIfZero(cfg.SSE.BucketKey),
+			}},
+		}
+	default:
+		return errors.New("missing configuration file or encryption algorithm")
+	}
+
+	_, err := c.client.PutBucketEncryption(c.ctx, &s3.PutBucketEncryptionInput{
+		Bucket:                            aws.String(bucket),
+		ServerSideEncryptionConfiguration: configuration,
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Controller) BucketEncryptionDelete(bucket string) error {
+	_, err := c.client.DeleteBucketEncryption(c.ctx, &s3.DeleteBucketEncryptionInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
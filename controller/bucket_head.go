@@ -2,13 +2,17 @@ package controller
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go"
+	"golang.org/x/sync/errgroup"
 )
 
-func (c *Controller) BucketHead(bucket string) error {
+func (c *Controller) BucketHead(bucket string, summary bool) error {
 	resp, err := c.client.HeadBucket(c.ctx, &s3.HeadBucketInput{
 		Bucket: aws.String(bucket),
 	})
@@ -16,6 +20,10 @@ func (c *Controller) BucketHead(bucket string) error {
 		return err
 	}
 
+	if summary {
+		return c.bucketSummary(bucket, resp)
+	}
+
 	b, err := json.MarshalIndent(resp, "", "  ")
 	if err != nil {
 		return err
@@ -25,3 +33,197 @@ func (c *Controller) BucketHead(bucket string) error {
 
 	return nil
 }
+
+type bucketSetting struct {
+	name  string
+	value func() (string, error)
+}
+
+// bucketSummary prints the configured bucket settings in a readable form.
+// Settings which can't be read (e.g. unsupported or access denied) show the error code.
+func (c *Controller) bucketSummary(bucket string, head *s3.HeadBucketOutput) error {
+	b := aws.String(bucket)
+
+	settings := []bucketSetting{
+		{"versioning", func() (string, error) {
+			resp, err := c.client.GetBucketVersioning(c.ctx, &s3.GetBucketVersioningInput{Bucket: b})
+			if err != nil {
+				return "", err
+			}
+			if resp.Status == "" {
+				return "never enabled", nil
+			}
+			return string(resp.Status), nil
+		}},
+		{"object lock", func() (string, error) {
+			resp, err := c.client.GetObjectLockConfiguration(c.ctx, &s3.GetObjectLockConfigurationInput{Bucket: b})
+			if err != nil {
+				return "", err
+			}
+			lock := resp.ObjectLockConfiguration
+			if lock == nil {
+				return "", nil
+			}
+			value := string(lock.ObjectLockEnabled)
+			if lock.Rule != nil && lock.Rule.DefaultRetention != nil {
+				retention := lock.Rule.DefaultRetention
+				value += fmt.Sprintf(", default %s for %d days %d years", retention.Mode, aws.ToInt32(retention.Days), aws.ToInt32(retention.Years))
+			}
+			return value, nil
+		}},
+		{"encryption", func() (string, error) {
+			resp, err := c.client.GetBucketEncryption(c.ctx, &s3.GetBucketEncryptionInput{Bucket: b})
+			if err != nil {
+				return "", err
+			}
+			if resp.ServerSideEncryptionConfiguration == nil {
+				return "", nil
+			}
+			var rules []string
+			for _, rule := range resp.ServerSideEncryptionConfiguration.Rules {
+				if def := rule.ApplyServerSideEncryptionByDefault; def != nil {
+					rules = append(rules, describeEncryption(def.SSEAlgorithm, def.KMSMasterKeyID, rule.BucketKeyEnabled, nil))
+				}
+			}
+			return strings.Join(rules, "; "), nil
+		}},
+		{"public access block", func() (string, error) {
+			resp, err := c.client.GetPublicAccessBlock(c.ctx, &s3.GetPublicAccessBlockInput{Bucket: b})
+			if err != nil {
+				return "", err
+			}
+			block := resp.PublicAccessBlockConfiguration
+			if block == nil {
+				return "", nil
+			}
+			return fmt.Sprintf("block ACLs: %t, ignore ACLs: %t, block policy: %t, restrict buckets: %t",
+				aws.ToBool(block.BlockPublicAcls),
+				aws.ToBool(block.IgnorePublicAcls),
+				aws.ToBool(block.BlockPublicPolicy),
+				aws.ToBool(block.RestrictPublicBuckets),
+			), nil
+		}},
+		{"ownership", func() (string, error) {
+			resp, err := c.client.GetBucketOwnershipControls(c.ctx, &s3.GetBucketOwnershipControlsInput{Bucket: b})
+			if err != nil {
+				return "", err
+			}
+			if resp.OwnershipControls == nil {
+				return "", nil
+			}
+			var rules []string
+			for _, rule := range resp.OwnershipControls.Rules {
+				rules = append(rules, string(rule.ObjectOwnership))
+			}
+			return strings.Join(rules, ", "), nil
+		}},
+		{"logging", func() (string, error) {
+			resp, err := c.client.GetBucketLogging(c.ctx, &s3.GetBucketLoggingInput{Bucket: b})
+			if err != nil {
+				return "", err
+			}
+			if resp.LoggingEnabled == nil {
+				return "disabled", nil
+			}
+			return fmt.Sprintf("to %s/%s", aws.ToString(resp.LoggingEnabled.TargetBucket), aws.ToString(resp.LoggingEnabled.TargetPrefix)), nil
+		}},
+		{"policy", func() (string, error) {
+			_, err := c.client.GetBucketPolicy(c.ctx, &s3.GetBucketPolicyInput{Bucket: b})
+			if err != nil {
+				return "", err
+			}
+			return "set", nil
+		}},
+		{"lifecycle", func() (string, error) {
+			resp, err := c.client.GetBucketLifecycleConfiguration(c.ctx, &s3.GetBucketLifecycleConfigurationInput{Bucket: b})
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("%d rules", len(resp.Rules)), nil
+		}},
+		{"replication", func() (string, error) {
+			resp, err := c.client.GetBucketReplication(c.ctx, &s3.GetBucketReplicationInput{Bucket: b})
+			if err != nil {
+				return "", err
+			}
+			if resp.ReplicationConfiguration == nil {
+				return "", nil
+			}
+			return fmt.Sprintf("%d rules", len(resp.ReplicationConfiguration.Rules)), nil
+		}},
+		{"website", func() (string, error) {
+			resp, err := c.client.GetBucketWebsite(c.ctx, &s3.GetBucketWebsiteInput{Bucket: b})
+			if err != nil {
+				return "", err
+			}
+			if resp.RedirectAllRequestsTo != nil {
+				return "redirect to " + aws.ToString(resp.RedirectAllRequestsTo.HostName), nil
+			}
+			if resp.IndexDocument != nil {
+				return "index " + aws.ToString(resp.IndexDocument.Suffix), nil
+			}
+			return "set", nil
+		}},
+		{"cors", func() (string, error) {
+			resp, err := c.client.GetBucketCors(c.ctx, &s3.GetBucketCorsInput{Bucket: b})
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("%d rules", len(resp.CORSRules)), nil
+		}},
+		{"tags", func() (string, error) {
+			tags, err := c.bucketTags(bucket)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("%d tags", len(tags)), nil
+		}},
+	}
+
+	values := make([]string, len(settings))
+
+	eg, _ := errgroup.WithContext(c.ctx)
+	eg.SetLimit(4)
+
+	for i, setting := range settings {
+		eg.Go(func() error {
+			value, err := setting.value()
+			if err != nil {
+				value = settingError(err)
+			}
+			if value == "" {
+				value = "not configured"
+			}
+			values[i] = value
+			return nil
+		})
+	}
+
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.OutWriter, "%-20s %s\n", "bucket:", bucket)
+	fmt.Fprintf(c.OutWriter, "%-20s %s\n", "region:", aws.ToString(head.BucketRegion))
+	for i, setting := range settings {
+		fmt.Fprintf(c.OutWriter, "%-20s %s\n", setting.name+":", values[i])
+	}
+
+	return nil
+}
+
+// settingError maps the "not configured" errors of the different
+// bucket settings to a single readable value.
+func settingError(err error) string {
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) {
+		return "error: " + err.Error()
+	}
+
+	code := apiErr.ErrorCode()
+	if strings.HasPrefix(code, "NoSuch") || strings.HasSuffix(code, "NotFoundError") {
+		return "not configured"
+	}
+
+	return "error: " + code
+}
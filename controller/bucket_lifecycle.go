@@ -55,6 +55,10 @@ func (c *Controller) BucketLifecyclePut(lifecyclePath, bucket string) error {
 }
 
 func (c *Controller) BucketLifecycleDelete(bucket string) error {
+	if err := c.confirm(bucket, fmt.Sprintf("Deleting the lifecycle policy of bucket %q.", bucket)); err != nil {
+		return err
+	}
+
 	_, err := c.client.DeleteBucketLifecycle(c.ctx, &s3.DeleteBucketLifecycleInput{
 		Bucket: aws.String(bucket),
 	})
@@ -0,0 +1,60 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+func (c *Controller) BucketLoggingGet(bucket string) error {
+	resp, err := c.client.GetBucketLogging(c.ctx, &s3.GetBucketLoggingInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		return err
+	}
+
+	// same format as accepted by 'logging put'
+	b, err := json.MarshalIndent(types.BucketLoggingStatus{
+		LoggingEnabled: resp.LoggingEnabled,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(b))
+
+	return nil
+}
+
+// BucketLoggingPut sets the access logging config,
+// a file without 'LoggingEnabled' disables the logging.
+func (c *Controller) BucketLoggingPut(loggingPath, bucket string) error {
+	lBytes, err := os.ReadFile(loggingPath)
+	if err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(bytes.NewBuffer(lBytes))
+	dec.DisallowUnknownFields()
+
+	var status *types.BucketLoggingStatus
+	if err := dec.Decode(&status); err != nil {
+		return fmt.Errorf("failed to unmarshal configuration file: %w", err)
+	}
+
+	_, err = c.client.PutBucketLogging(c.ctx, &s3.PutBucketLoggingInput{
+		Bucket:              aws.String(bucket),
+		BucketLoggingStatus: status,
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,166 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+func (c *Controller) BucketNotificationGet(bucket string) error {
+	configuration, err := c.bucketNotification(bucket)
+	if err != nil {
+		return err
+	}
+
+	b, err := json.MarshalIndent(configuration, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(b))
+
+	return nil
+}
+
+func (c *Controller) bucketNotification(bucket string) (*types.NotificationConfiguration, error) {
+	resp, err := c.client.GetBucketNotificationConfiguration(c.ctx, &s3.GetBucketNotificationConfigurationInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// same format as accepted by 'notification put'
+	return &types.NotificationConfiguration{
+		EventBridgeConfiguration:     resp.EventBridgeConfiguration,
+		LambdaFunctionConfigurations: resp.LambdaFunctionConfigurations,
+		QueueConfigurations:          resp.QueueConfigurations,
+		TopicConfigurations:          resp.TopicConfigurations,
+	}, nil
+}
+
+func (c *Controller) BucketNotificationPut(notificationPath, bucket string) error {
+	nBytes, err := os.ReadFile(notificationPath)
+	if err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(bytes.NewBuffer(nBytes))
+	dec.DisallowUnknownFields()
+
+	var configuration *types.NotificationConfiguration
+	if err := dec.Decode(&configuration); err != nil {
+		return fmt.Errorf("failed to unmarshal configuration file: %w", err)
+	}
+
+	return c.bucketNotificationPut(bucket, configuration)
+}
+
+func (c *Controller) bucketNotificationPut(bucket string, configuration *types.NotificationConfiguration) error {
+	_, err := c.client.PutBucketNotificationConfiguration(c.ctx, &s3.PutBucketNotificationConfigurationInput{
+		Bucket:                    aws.String(bucket),
+		NotificationConfiguration: configuration,
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// BucketNotificationDelete removes all notifications, S3 has no dedicated API
+// for it and expects an empty configuration instead.
+func (c *Controller) BucketNotificationDelete(bucket string) error {
+	if err := c.confirm(bucket, fmt.Sprintf("Deleting all notifications of bucket %q.", bucket)); err != nil {
+		return err
+	}
+
+	return c.bucketNotificationPut(bucket, &types.NotificationConfiguration{})
+}
+
+type BucketNotificationAddConfig struct {
+	ID        string
+	TargetARN string
+	Events    []string
+	Prefix    string
+	Suffix    string
+}
+
+// BucketNotificationAdd merges a single notification into the existing configuration.
+// The type of the notification is derived from the service of the target ARN.
+func (c *Controller) BucketNotificationAdd(bucket string, cfg BucketNotificationAddConfig) error {
+	if len(cfg.Events) == 0 {
+		return errors.New("missing events")
+	}
+
+	events := make([]types.Event, 0, len(cfg.Events))
+	for _, event := range cfg.Events {
+		events = append(events, types.Event(event))
+	}
+
+	var filter *types.NotificationConfigurationFilter
+	if cfg.Prefix != "" || cfg.Suffix != "" {
+		filter = &types.NotificationConfigurationFilter{Key: &types.S3KeyFilter{}}
+		if cfg.Prefix != "" {
+			filter.Key.FilterRules = append(filter.Key.FilterRules, types.FilterRule{
+				Name:  types.FilterRuleNamePrefix,
+				Value: aws.String(cfg.Prefix),
+			})
+		}
+		if cfg.Suffix != "" {
+			filter.Key.FilterRules = append(filter.Key.FilterRules, types.FilterRule{
+				Name:  types.FilterRuleNameSuffix,
+				Value: aws.String(cfg.Suffix),
+			})
+		}
+	}
+
+	configuration, err := c.bucketNotification(bucket)
+	if err != nil {
+		return err
+	}
+
+	var (
+		id      = aws.String(cfg.ID)
+		arn     = aws.String(cfg.TargetARN)
+		service = arnService(cfg.TargetARN)
+	)
+	if cfg.ID == "" {
+		id = nil
+	}
+
+	switch service {
+	case "sqs":
+		configuration.QueueConfigurations = append(configuration.QueueConfigurations, types.QueueConfiguration{
+			Id: id, QueueArn: arn, Events: events, Filter: filter,
+		})
+	case "sns":
+		configuration.TopicConfigurations = append(configuration.TopicConfigurations, types.TopicConfiguration{
+			Id: id, TopicArn: arn, Events: events, Filter: filter,
+		})
+	case "lambda":
+		configuration.LambdaFunctionConfigurations = append(configuration.LambdaFunctionConfigurations, types.LambdaFunctionConfiguration{
+			Id: id, LambdaFunctionArn: arn, Events: events, Filter: filter,
+		})
+	default:
+		return fmt.Errorf("unsupported target ARN %q, expected an 'sqs', 'sns' or 'lambda' ARN", cfg.TargetARN)
+	}
+
+	return c.bucketNotificationPut(bucket, configuration)
+}
+
+// arnService returns the service of an ARN (arn:partition:service:region:account:resource).
+func arnService(arn string) string {
+	parts := strings.SplitN(arn, ":", 4)
+	if len(parts) < 4 || parts[0] != "arn" {
+		return ""
+	}
+	return parts[2]
+}
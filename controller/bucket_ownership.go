@@ -0,0 +1,59 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+func (c *Controller) BucketOwnershipGet(bucket string) error {
+	resp, err := c.client.GetBucketOwnershipControls(c.ctx, &s3.GetBucketOwnershipControlsInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		return err
+	}
+
+	b, err := json.MarshalIndent(resp.OwnershipControls, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(b))
+
+	return nil
+}
+
+func (c *Controller) BucketOwnershipPut(bucket, ownership string) error {
+	objectOwnership := types.ObjectOwnership(ownership)
+	if !slices.Contains(objectOwnership.Values(), objectOwnership) {
+		return fmt.Errorf("invalid object ownership %q (use one of %v)", ownership, objectOwnership.Values())
+	}
+
+	_, err := c.client.PutBucketOwnershipControls(c.ctx, &s3.PutBucketOwnershipControlsInput{
+		Bucket: aws.String(bucket),
+		OwnershipControls: &types.OwnershipControls{
+			Rules: []types.OwnershipControlsRule{{ObjectOwnership: objectOwnership}},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Controller) BucketOwnershipDelete(bucket string) error {
+	_, err := c.client.DeleteBucketOwnershipControls(c.ctx, &s3.DeleteBucketOwnershipControlsInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
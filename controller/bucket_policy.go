@@ -39,6 +39,10 @@ func (c *Controller) BucketPolicyPut(policyPath, bucket string) error {
 }
 
 func (c *Controller) BucketPolicyDelete(bucket string) error {
+	if err := c.confirm(bucket, fmt.Sprintf("Deleting the policy of bucket %q.", bucket)); err != nil {
+		return err
+	}
+
 	_, err := c.client.DeleteBucketPolicy(c.ctx, &s3.DeleteBucketPolicyInput{
 		Bucket: aws.String(bucket),
 	})
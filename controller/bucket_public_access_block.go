@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+func (c *Controller) BucketPublicAccessBlockGet(bucket string) error {
+	resp, err := c.client.GetPublicAccessBlock(c.ctx, &s3.GetPublicAccessBlockInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		return err
+	}
+
+	b, err := json.MarshalIndent(resp.PublicAccessBlockConfiguration, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(b))
+
+	return nil
+}
+
+func (c *Controller) BucketPublicAccessBlockPut(configPath, bucket string) error {
+	pBytes, err := os.ReadFile(configPath)
+	if err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(bytes.NewBuffer(pBytes))
+	dec.DisallowUnknownFields()
+
+	var configuration *types.PublicAccessBlockConfiguration
+	if err := dec.Decode(&configuration); err != nil {
+		return fmt.Errorf("failed to unmarshal configuration file: %w", err)
+	}
+
+	_, err = c.client.PutPublicAccessBlock(c.ctx, &s3.PutPublicAccessBlockInput{
+		Bucket:                         aws.String(bucket),
+		PublicAccessBlockConfiguration: configuration,
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Controller) BucketPublicAccessBlockDelete(bucket string) error {
+	if err := c.confirm(bucket, fmt.Sprintf("Deleting the public access block of bucket %q.", bucket)); err != nil {
+		return err
+	}
+
+	_, err := c.client.DeletePublicAccessBlock(c.ctx, &s3.DeletePublicAccessBlockInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/sj14/sss/util"
+)
+
+func (c *Controller) BucketReplicationGet(bucket string) error {
+	resp, err := c.client.GetBucketReplication(c.ctx, &s3.GetBucketReplicationInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		return err
+	}
+
+	b, err := json.MarshalIndent(resp.ReplicationConfiguration, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(b))
+
+	return nil
+}
+
+// BucketReplicationPut reads the replication configuration from a JSON file or,
+// when the file has the '.toml' extension, from a TOML file.
+func (c *Controller) BucketReplicationPut(replicationPath, bucket string) error {
+	rBytes, err := os.ReadFile(replicationPath)
+	if err != nil {
+		return err
+	}
+
+	var configuration *types.ReplicationConfiguration
+
+	if strings.EqualFold(filepath.Ext(replicationPath), ".toml") {
+		md, err := toml.NewDecoder(bytes.NewBuffer(rBytes)).Decode(&configuration)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal configuration file: %w", err)
+		}
+		if undecoded := md.Undecoded(); len(undecoded) > 0 {
+			return fmt.Errorf("unknown fields in configuration file: %v", undecoded)
+		}
+	} else {
+		dec := json.NewDecoder(bytes.NewBuffer(rBytes))
+		dec.DisallowUnknownFields()
+
+		if err := dec.Decode(&configuration); err != nil {
+			return fmt.Errorf("failed to unmarshal configuration file: %w", err)
+		}
+	}
+
+	_, err = c.client.PutBucketReplication(c.ctx, &s3.PutBucketReplicationInput{
+		Bucket:                   aws.String(bucket),
+		ReplicationConfiguration: configuration,
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Controller) BucketReplicationDelete(bucket string) error {
+	if err := c.confirm(bucket, fmt.Sprintf("Deleting the replication config of bucket %q.", bucket)); err != nil {
+		return err
+	}
+
+	_, err := c.client.DeleteBucketReplication(c.ctx, &s3.DeleteBucketReplicationInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ObjectReplicationStatus prints the replication status of the object,
+// which is only set when the object matches a replication rule.
+func (c *Controller) ObjectReplicationStatus(bucket, key, versionID string) error {
+	resp, err := c.client.HeadObject(c.ctx, &s3.HeadObjectInput{
+		Bucket:    aws.String(bucket),
+		Key:       aws.String(key),
+		VersionId: util.NilIfZero(versionID),
+	})
+	if err != nil {
+		return err
+	}
+
+	status := string(resp.ReplicationStatus)
+	if status == "" {
+		status = "NONE (no matching replication rule)"
+	}
+
+	fmt.Fprintf(c.OutWriter, "%s: %s\n", key, status)
+
+	return nil
+}
@@ -1,20 +1,34 @@
 package controller
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"slices"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
 )
 
 func (c *Controller) BucketTagging(bucket string) error {
 	resp, err := c.client.GetBucketTagging(c.ctx, &s3.GetBucketTaggingInput{
 		Bucket: aws.String(bucket),
 	})
+	if isNoSuchTagSet(err) {
+		resp, err = &s3.GetBucketTaggingOutput{}, nil
+	}
 	if err != nil {
 		return err
 	}
+	if resp.TagSet == nil {
+		// same format as with tags
+		resp.TagSet = []types.Tag{}
+	}
 
 	b, err := json.MarshalIndent(resp, "", "  ")
 	if err != nil {
@@ -25,3 +39,146 @@ func (c *Controller) BucketTagging(bucket string) error {
 
 	return nil
 }
+
+// bucketTags returns the tags of the bucket, an empty list when no tags are set.
+func (c *Controller) bucketTags(bucket string) ([]types.Tag, error) {
+	resp, err := c.client.GetBucketTagging(c.ctx, &s3.GetBucketTaggingInput{
+		Bucket: aws.String(bucket),
+	})
+	if isNoSuchTagSet(err) {
+		return []types.Tag{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if resp.TagSet == nil {
+		return []types.Tag{}, nil
+	}
+
+	return resp.TagSet, nil
+}
+
+type BucketTaggingPutConfig struct {
+	// Tags in the format 'key=value'.
+	Tags []string
+	// FilePath to a JSON file with the tag set.
+	FilePath string
+	// Merge the tags into the existing tags instead of replacing all tags.
+	Merge bool
+}
+
+func (c *Controller) BucketTaggingPut(bucket string, cfg BucketTaggingPutConfig) error {
+	tags, err := parseTags(cfg.Tags)
+	if err != nil {
+		return err
+	}
+
+	if cfg.FilePath != "" {
+		fileTags, err := readTagsFile(cfg.FilePath)
+		if err != nil {
+			return err
+		}
+		tags = mergeTags(fileTags, tags)
+	}
+
+	if len(tags) == 0 {
+		return errors.New("no tags specified")
+	}
+
+	if cfg.Merge {
+		existing, err := c.bucketTags(bucket)
+		if err != nil {
+			return err
+		}
+		tags = mergeTags(existing, tags)
+	}
+
+	_, err = c.client.PutBucketTagging(c.ctx, &s3.PutBucketTaggingInput{
+		Bucket:  aws.String(bucket),
+		Tagging: &types.Tagging{TagSet: tags},
+	})
+	return err
+}
+
+// BucketTaggingDelete removes the given tag keys or all tags when no keys are given.
+func (c *Controller) BucketTaggingDelete(bucket string, keys []string) error {
+	if len(keys) > 0 {
+		existing, err := c.bucketTags(bucket)
+		if err != nil {
+			return err
+		}
+
+		remaining := removeTags(existing, keys)
+		if len(remaining) > 0 {
+			_, err = c.client.PutBucketTagging(c.ctx, &s3.PutBucketTaggingInput{
+				Bucket:  aws.String(bucket),
+				Tagging: &types.Tagging{TagSet: remaining},
+			})
+			return err
+		}
+	}
+
+	_, err := c.client.DeleteBucketTagging(c.ctx, &s3.DeleteBucketTaggingInput{
+		Bucket: aws.String(bucket),
+	})
+	return err
+}
+
+func isNoSuchTagSet(err error) bool {
+	var apiErr smithy.APIError
+	return errors.As(err, &apiErr) && apiErr.ErrorCode() == "NoSuchTagSet"
+}
+
+// parseTags parses tags in the format 'key=value'.
+func parseTags(pairs []string) ([]types.Tag, error) {
+	var tags []types.Tag
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid tag %q, expected format: 'key=value'", pair)
+		}
+		tags = mergeTags(tags, []types.Tag{{Key: aws.String(key), Value: aws.String(value)}})
+	}
+	return tags, nil
+}
+
+func readTagsFile(tagsPath string) ([]types.Tag, error) {
+	tBytes, err := os.ReadFile(tagsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewBuffer(tBytes))
+	dec.DisallowUnknownFields()
+
+	var tagging types.Tagging
+	if err := dec.Decode(&tagging); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal tags file: %w", err)
+	}
+
+	return tagging.TagSet, nil
+}
+
+// mergeTags returns the existing tags updated with the given tags.
+func mergeTags(existing, tags []types.Tag) []types.Tag {
+	merged := slices.Clone(existing)
+
+	for _, tag := range tags {
+		idx := slices.IndexFunc(merged, func(t types.Tag) bool {
+			return aws.ToString(t.Key) == aws.ToString(tag.Key)
+		})
+		if idx >= 0 {
+			merged[idx] = tag
+			continue
+		}
+		merged = append(merged, tag)
+	}
+
+	return merged
+}
+
+func removeTags(existing []types.Tag, keys []string) []types.Tag {
+	return slices.DeleteFunc(slices.Clone(existing), func(t types.Tag) bool {
+		return slices.Contains(keys, aws.ToString(t.Key))
+	})
+}
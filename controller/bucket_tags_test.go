@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/shoenig/test/must"
+)
+
+func tag(key, value string) types.Tag {
+	return types.Tag{Key: aws.String(key), Value: aws.String(value)}
+}
+
+func TestParseTags(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		pairs   []string
+		want    []types.Tag
+		wantErr bool
+	}{
+		{name: "empty"},
+		{name: "single", pairs: []string{"env=prod"}, want: []types.Tag{tag("env", "prod")}},
+		{name: "empty value", pairs: []string{"env="}, want: []types.Tag{tag("env", "")}},
+		{name: "value with separator", pairs: []string{"query=a=b"}, want: []types.Tag{tag("query", "a=b")}},
+		{name: "duplicate key", pairs: []string{"env=dev", "env=prod"}, want: []types.Tag{tag("env", "prod")}},
+		{name: "missing separator", pairs: []string{"env"}, wantErr: true},
+		{name: "empty key", pairs: []string{"=prod"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTags(tt.pairs)
+			if tt.wantErr {
+				must.Error(t, err)
+				return
+			}
+			must.NoError(t, err)
+			must.Eq(t, tt.want, got)
+		})
+	}
+}
+
+func TestMergeTags(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		existing []types.Tag
+		tags     []types.Tag
+		want     []types.Tag
+	}{
+		{name: "empty"},
+		{name: "add to empty", tags: []types.Tag{tag("a", "1")}, want: []types.Tag{tag("a", "1")}},
+		{name: "keep existing", existing: []types.Tag{tag("a", "1")}, want: []types.Tag{tag("a", "1")}},
+		{
+			name:     "add and overwrite in place",
+			existing: []types.Tag{tag("a", "1"), tag("b", "2")},
+			tags:     []types.Tag{tag("c", "3"), tag("a", "9")},
+			want:     []types.Tag{tag("a", "9"), tag("b", "2"), tag("c", "3")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			existing := append([]types.Tag(nil), tt.existing...)
+
+			must.Eq(t, tt.want, mergeTags(tt.existing, tt.tags))
+			// the existing tags are not modified
+			must.Eq(t, existing, tt.existing)
+		})
+	}
+}
+
+func TestRemoveTags(t *testing.T) {
+	t.Parallel()
+
+	existing := []types.Tag{tag("a", "1"), tag("b", "2"), tag("c", "3")}
+
+	tests := []struct {
+		name string
+		keys []string
+		want []types.Tag
+	}{
+		{name: "none", want: existing},
+		{name: "one", keys: []string{"b"}, want: []types.Tag{tag("a", "1"), tag("c", "3")}},
+		{name: "unknown", keys: []string{"x"}, want: existing},
+		{name: "all", keys: []string{"a", "b", "c"}, want: []types.Tag{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			must.Eq(t, tt.want, removeTags(existing, tt.keys))
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+func (c *Controller) BucketWebsiteGet(bucket string) error {
+	resp, err := c.client.GetBucketWebsite(c.ctx, &s3.GetBucketWebsiteInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		return err
+	}
+
+	// same format as accepted by 'website put'
+	b, err := json.MarshalIndent(types.WebsiteConfiguration{
+		ErrorDocument:         resp.ErrorDocument,
+		IndexDocument:         resp.IndexDocument,
+		RedirectAllRequestsTo: resp.RedirectAllRequestsTo,
+		RoutingRules:          resp.RoutingRules,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(b))
+
+	return nil
+}
+
+type BucketWebsitePutConfig struct {
+	// FilePath to a JSON file with the website configuration.
+	FilePath      string
+	IndexDocument string
+	ErrorDocument string
+	// RedirectAllTo redirects all requests to the given URL, e.g. 'https://example.com'.
+	RedirectAllTo string
+	// Redirects in the format 'old-prefix=new-prefix'.
+	Redirects []string
+}
+
+func (c *Controller) BucketWebsitePut(bucket string, cfg BucketWebsitePutConfig) error {
+	configuration, err := cfg.configuration()
+	if err != nil {
+		return err
+	}
+
+	_, err = c.client.PutBucketWebsite(c.ctx, &s3.PutBucketWebsiteInput{
+		Bucket:               aws.String(bucket),
+		WebsiteConfiguration: configuration,
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (cfg BucketWebsitePutConfig) configuration() (*types.WebsiteConfiguration, error) {
+	flagsSet := cfg.IndexDocument != "" || cfg.ErrorDocument != "" || cfg.RedirectAllTo != "" || len(cfg.Redirects) > 0
+
+	if cfg.FilePath != "" {
+		if flagsSet {
+			return nil, errors.New("configuration file and website flags can't be combined")
+		}
+
+		wBytes, err := os.ReadFile(cfg.FilePath)
+		if err != nil {
+			return nil, err
+		}
+
+		dec := json.NewDecoder(bytes.NewBuffer(wBytes))
+		dec.DisallowUnknownFields()
+
+		var configuration *types.WebsiteConfiguration
+		if err := dec.Decode(&configuration); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal configuration file: %w", err)
+		}
+		return configuration, nil
+	}
+
+	if cfg.RedirectAllTo != "" {
+		if cfg.IndexDocument != "" || cfg.ErrorDocument != "" || len(cfg.Redirects) > 0 {
+			return nil, errors.New("redirecting all requests can't be combined with other website settings")
+		}
+
+		u, err := url.Parse(cfg.RedirectAllTo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse redirect URL: %w", err)
+		}
+		if u.Host == "" {
+			// e.g. 'example.com' without a scheme
+			u = &url.URL{Host: cfg.RedirectAllTo}
+		}
+
+		return &types.WebsiteConfiguration{
+			RedirectAllRequestsTo: &types.RedirectAllRequestsTo{
+				HostName: aws.String(u.Host),
+				Protocol: types.Protocol(u.Scheme),
+			},
+		}, nil
+	}
+
+	if cfg.IndexDocument == "" {
+		return nil, errors.New("missing index document")
+	}
+
+	configuration := &types.WebsiteConfiguration{
+		IndexDocument: &types.IndexDocument{Suffix: aws.String(cfg.IndexDocument)},
+	}
+	if cfg.ErrorDocument != "" {
+		configuration.ErrorDocument = &types.ErrorDocument{Key: aws.String(cfg.ErrorDocument)}
+	}
+
+	for _, redirect := range cfg.Redirects {
+		from, to, ok := strings.Cut(redirect, "=")
+		if !ok || from == "" {
+			return nil, fmt.Errorf("invalid redirect %q, expected format: 'old-prefix=new-prefix'", redirect)
+		}
+		configuration.RoutingRules = append(configuration.RoutingRules, types.RoutingRule{
+			Condition: &types.Condition{KeyPrefixEquals: aws.String(from)},
+			Redirect:  &types.Redirect{ReplaceKeyPrefixWith: aws.String(to)},
+		})
+	}
+
+	return configuration, nil
+}
+
+func (c *Controller) BucketWebsiteDelete(bucket string) error {
+	_, err := c.client.DeleteBucketWebsite(c.ctx, &s3.DeleteBucketWebsiteInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
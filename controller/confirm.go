@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+)
+
+// confirm asks the operator to type the bucket name before a destructive operation.
+// Without a terminal, the confirmation is skipped unless confirm_destructive is set.
+func (c *Controller) confirm(bucket, action string) error {
+	if c.assumeYes {
+		return nil
+	}
+
+	if !c.interactive {
+		if c.confirmDestructive {
+			return errors.New("destructive command requires confirmation, use --yes in non-interactive sessions")
+		}
+		return nil
+	}
+
+	fmt.Fprintf(c.ErrWriter, "%s\nType the bucket name %q to confirm: ", action, bucket)
+
+	answer, err := bufio.NewReader(c.inReader).ReadString('\n')
+	if err != nil {
+		return fmt.Errorf("failed to read confirmation: %w", err)
+	}
+
+	if strings.TrimSpace(answer) != bucket {
+		return errors.New("confirmation failed, aborted")
+	}
+
+	return nil
+}
+
+// confirmDelete asks for confirmation of a bulk deletion. The preview of the
+// affected data requires a full listing, so it's only built when prompting.
+func (c *Controller) confirmDelete(bucket, prefix string, allVersions bool, action func(preview string) string) error {
+	if c.assumeYes || !c.interactive {
+		return c.confirm(bucket, action(""))
+	}
+
+	preview, err := c.deletePreview(bucket, prefix, allVersions)
+	if err != nil {
+		return err
+	}
+
+	return c.confirm(bucket, action(preview))
+}
+
+// deletePreview describes the amount of data affected by a bulk deletion.
+func (c *Controller) deletePreview(bucket, prefix string, allVersions bool) (string, error) {
+	var count, size uint64
+
+	if allVersions {
+		for resp, err := range c.objectVersions(bucket, prefix, "") {
+			if err != nil {
+				return "", err
+			}
+			for _, v := range resp.Versions {
+				count++
+				size += uint64(*v.Size)
+			}
+			count += uint64(len(resp.DeleteMarkers))
+		}
+	} else {
+		for resp, err := range c.objectList(bucket, prefix, "") {
+			if err != nil {
+				return "", err
+			}
+			for _, o := range resp.Contents {
+				count++
+				size += uint64(*o.Size)
+			}
+		}
+	}
+
+	return fmt.Sprintf("%d objects (%s)", count, humanize.IBytes(size)), nil
+}
@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// sniffLen is the number of bytes http.DetectContentType considers.
+const sniffLen = 512
+
+// normalizeMimeTypes lowercases the extensions of the [mime] config table
+// and ensures they have a leading dot.
+func normalizeMimeTypes(types map[string]string) (map[string]string, error) {
+	normalized := make(map[string]string, len(types))
+	for ext, mimeType := range types {
+		if _, _, err := mime.ParseMediaType(mimeType); err != nil {
+			return nil, fmt.Errorf("invalid mime type %q for %q: %w", mimeType, ext, err)
+		}
+		if !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+		normalized[strings.ToLower(ext)] = mimeType
+	}
+	return normalized, nil
+}
+
+// detectContentType determines the content type by the extension of the key,
+// the custom mappings take precedence over the system mappings.
+// When the extension is unknown, the beginning of the body is sniffed.
+// The returned reader must be used instead of the passed body.
+func (c *Controller) detectContentType(key string, body io.Reader) (string, io.Reader) {
+	ext := strings.ToLower(path.Ext(key))
+	if ext != "" {
+		if mimeType, ok := c.mimeTypes[ext]; ok {
+			return mimeType, body
+		}
+		if mimeType := mime.TypeByExtension(ext); mimeType != "" {
+			return mimeType, body
+		}
+	}
+
+	br := bufio.NewReaderSize(body, sniffLen)
+	head, _ := br.Peek(sniffLen) // a shorter body or an error is handled when reading
+	return http.DetectContentType(head), br
+}
+
+// parseMetadata parses user metadata in the format 'key=value'.
+func parseMetadata(pairs []string) (map[string]string, error) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+
+	metadata := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid metadata %q, expected format: 'key=value'", pair)
+		}
+		metadata[strings.ToLower(key)] = value
+	}
+	return metadata, nil
+}
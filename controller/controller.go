@@ -2,13 +2,14 @@ package controller
 
 import (
 	"context"
-	"crypto/tls"
+	"errors"
 	"fmt"
 	"io"
-	"log"
 	"net"
 	"net/http"
 	"os"
+	"sync/atomic"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/aws/middleware"
@@ -17,46 +18,83 @@ import (
 	"github.com/aws/smithy-go/logging"
 	smithymiddleware "github.com/aws/smithy-go/middleware"
 	smithyhttp "github.com/aws/smithy-go/transport/http"
-	"github.com/dustin/go-humanize"
 	"github.com/sj14/sss/util"
+	"github.com/sj14/sss/util/idletimeout"
 	"github.com/sj14/sss/util/ratelimiter"
 	"golang.org/x/time/rate"
 )
 
 type Controller struct {
-	ctx       context.Context
-	OutWriter io.Writer
-	ErrWriter io.Writer
-	client    *s3.Client
-	verbosity uint8
+	ctx                context.Context
+	OutWriter          io.Writer
+	ErrWriter          io.Writer
+	inReader           io.Reader
+	interactive        bool
+	assumeYes          bool
+	confirmDestructive bool
+	auditPath          string
+	mimeTypes          map[string]string
+	transport          *TransportWrapper
+	client             *s3.Client
+	verbosity          uint8
 }
 
 type ControllerConfig struct {
-	OutWriter io.Writer
-	ErrWriter io.Writer
-	Profile   Profile
-	Verbosity uint8
-	Headers   map[string]string
-	Params    map[string]string
-	DryRun    bool
-	BuildInfo util.BuildInfo
+	OutWriter   io.Writer
+	ErrWriter   io.Writer
+	Profile     Profile
+	ProfileName string
+	Verbosity   uint8
+	Headers     map[string]string
+	Params      map[string]string
+	DryRun      bool
+	BuildInfo   util.BuildInfo
+	InReader    io.Reader
+	Interactive bool
+	AssumeYes   bool
+	MimeTypes   map[string]string
 }
 
 type Config struct {
 	Profiles map[string]Profile `toml:"profiles"`
+	// Mime maps file extensions to content types, e.g. md = "text/markdown".
+	Mime map[string]string `toml:"mime"`
 }
 
 type Profile struct {
-	Endpoint  string `toml:"endpoint"`
-	Region    string `toml:"region"`
-	AccessKey string `toml:"access_key"`
-	SecretKey string `toml:"secret_key"`
-	PathStyle bool   `toml:"path_style"`
-	Insecure  bool   `toml:"insecure"`
-	ReadOnly  bool   `toml:"read_only"`
-	SNI       string `toml:"sni"`
-	Network   string `toml:"network"`
-	Bandwidth string `toml:"bandwidth"`
+	Endpoint           string        `toml:"endpoint"`
+	Region             string        `toml:"region"`
+	AccessKey          string        `toml:"access_key"`
+	SecretKey          string        `toml:"secret_key"`
+	PathStyle          bool          `toml:"path_style"`
+	Insecure           bool          `toml:"insecure"`
+	ReadOnly           bool          `toml:"read_only"`
+	SNI                string        `toml:"sni"`
+	Network            string        `toml:"network"`
+	Bandwidth          string        `toml:"bandwidth"`
+	UploadBandwidth    string        `toml:"upload_bandwidth"`
+	DownloadBandwidth  string        `toml:"download_bandwidth"`
+	BandwidthSchedule  []string      `toml:"bandwidth_schedule"`
+	CAFile             string        `toml:"ca_file"`
+	ClientCertFile     string        `toml:"client_cert_file"`
+	ClientKeyFile      string        `toml:"client_key_file"`
+	TLSMinVersion      string        `toml:"tls_min_version"`
+	Proxy              string        `toml:"proxy"`
+	NoProxy            string        `toml:"no_proxy"`
+	MaxRetries         *int          `toml:"max_retries"`
+	RetryMode          string        `toml:"retry_mode"`
+	MaxBackoff         time.Duration `toml:"max_backoff"`
+	ConnectTimeout     time.Duration `toml:"connect_timeout"`
+	RequestTimeout     time.Duration `toml:"request_timeout"`
+	IdleReadTimeout    time.Duration `toml:"idle_read_timeout"`
+	AllowBuckets       []string      `toml:"allow_buckets"`
+	DenyBuckets        []string      `toml:"deny_buckets"`
+	DenyOperations     []string      `toml:"deny_operations"`
+	ConfirmDestructive bool          `toml:"confirm_destructive"`
+	Trash              bool          `toml:"trash"`
+	AuditLog           string        `toml:"audit_log"`
+	RequestsPerSecond  float64       `toml:"requests_per_second"`
+	RequestBurst       int           `toml:"request_burst"`
 }
 
 func New(ctx context.Context, cfg ControllerConfig) (*Controller, error) {
@@ -73,6 +111,29 @@ func New(ctx context.Context, cfg ControllerConfig) (*Controller, error) {
 		}
 	}
 
+	permissions, err := NewPermissionMiddleware(cfg.Profile)
+	if err != nil {
+		return nil, err
+	}
+
+	auditPath := cfg.Profile.AuditLog
+	switch auditPath {
+	case AuditLogDisabled:
+		auditPath = ""
+	case "":
+		auditPath, err = DefaultAuditLogPath()
+		if err != nil {
+			// e.g. no home directory in minimal containers, don't block all commands
+			fmt.Fprintf(cfg.ErrWriter, "audit log disabled: %v\n", err)
+			auditPath = ""
+		}
+	}
+
+	mimeTypes, err := normalizeMimeTypes(cfg.MimeTypes)
+	if err != nil {
+		return nil, err
+	}
+
 	clientOptions := []func(o *s3.Options){
 		func(o *s3.Options) { o.UsePathStyle = cfg.Profile.PathStyle },
 		func(o *s3.Options) {
@@ -112,6 +173,35 @@ func New(ctx context.Context, cfg ControllerConfig) (*Controller, error) {
 				},
 			)
 		},
+		func(o *s3.Options) {
+			if !permissions.Enabled() {
+				return
+			}
+			o.APIOptions = append(o.APIOptions,
+				func(stack *smithymiddleware.Stack) error {
+					return stack.Initialize.Add(permissions, smithymiddleware.Before)
+				},
+			)
+		},
+		func(o *s3.Options) {
+			if auditPath == "" {
+				return
+			}
+			audit := &AuditMiddleware{
+				Path:      auditPath,
+				Profile:   cfg.ProfileName,
+				Endpoint:  cfg.Profile.Endpoint,
+				ErrWriter: cfg.ErrWriter,
+			}
+			o.APIOptions = append(o.APIOptions,
+				func(stack *smithymiddleware.Stack) error {
+					if err := stack.Initialize.Add(audit, smithymiddleware.Before); err != nil {
+						return err
+					}
+					return stack.Finalize.Add(&auditRequestMiddleware{}, smithymiddleware.After)
+				},
+			)
+		},
 	}
 
 	awsCfg := aws.Config{
@@ -126,8 +216,8 @@ func New(ctx context.Context, cfg ControllerConfig) (*Controller, error) {
 		)
 	}
 
-	if cfg.Profile.ReadOnly {
-		awsCfg.RetryMaxAttempts = 1
+	if err := configureRetries(&awsCfg, cfg.Profile); err != nil {
+		return nil, err
 	}
 
 	if cfg.Verbosity >= 9 {
@@ -146,40 +236,59 @@ func New(ctx context.Context, cfg ControllerConfig) (*Controller, error) {
 			aws.LogRetries
 	}
 
-	clientOptions = append(clientOptions, func(o *s3.Options) {
-		baseTransport := http.DefaultTransport.(*http.Transport).Clone()
+	tlsClientConfig, err := tlsConfig(cfg.Profile)
+	if err != nil {
+		return nil, err
+	}
 
-		if baseTransport.TLSClientConfig == nil {
-			baseTransport.TLSClientConfig = &tls.Config{}
-		}
-		if cfg.Profile.Insecure {
-			baseTransport.TLSClientConfig.InsecureSkipVerify = true
-		}
-		if cfg.Profile.SNI != "" {
-			baseTransport.TLSClientConfig.ServerName = cfg.Profile.SNI
+	if cfg.Profile.Network == "" {
+		cfg.Profile.Network = "tcp"
+	}
+
+	// keep the proxy settings from the environment when no proxy is configured
+	proxy := http.ProxyFromEnvironment
+	if cfg.Profile.Proxy != "" {
+		proxy, err = proxyFunc(cfg.Profile.Proxy, cfg.Profile.NoProxy)
+		if err != nil {
+			return nil, err
 		}
+	}
+
+	transportWrapper := &TransportWrapper{
+		ReadOnly:        cfg.Profile.ReadOnly,
+		IdleReadTimeout: cfg.Profile.IdleReadTimeout,
+	}
+
+	transportWrapper.UploadLimiter, transportWrapper.DownloadLimiter, err = bandwidthLimiters(ctx, cfg.Profile)
+	if err != nil {
+		return nil, err
+	}
 
+	if cfg.Profile.RequestsPerSecond < 0 || cfg.Profile.RequestBurst < 0 {
+		return nil, errors.New("requests_per_second and request_burst must not be negative")
+	}
+	if cfg.Profile.RequestsPerSecond > 0 {
+		transportWrapper.RequestLimiter = rate.NewLimiter(
+			rate.Limit(cfg.Profile.RequestsPerSecond),
+			max(1, cfg.Profile.RequestBurst),
+		)
+	}
+
+	clientOptions = append(clientOptions, func(o *s3.Options) {
+		baseTransport := http.DefaultTransport.(*http.Transport).Clone()
+		baseTransport.TLSClientConfig = tlsClientConfig
+		baseTransport.Proxy = proxy
+
+		// also used for connecting to the proxy
 		baseTransport.DialContext = func(ctx context.Context, _, addr string) (net.Conn, error) {
-			dialer := net.Dialer{}
+			dialer := net.Dialer{Timeout: cfg.Profile.ConnectTimeout}
 			return dialer.DialContext(ctx, cfg.Profile.Network, addr)
 		}
 
-		transportWrapper := &TransportWrapper{
-			Base:     baseTransport,
-			ReadOnly: cfg.Profile.ReadOnly,
-		}
-
-		if cfg.Profile.Bandwidth != "" {
-			bandwidth, err := humanize.ParseBytes(cfg.Profile.Bandwidth)
-			if err != nil {
-				log.Fatalln(err)
-			}
+		// only wait for the response headers, stalled bodies are aborted by the idle read timeout
+		baseTransport.ResponseHeaderTimeout = cfg.Profile.RequestTimeout
 
-			transportWrapper.Limiter = rate.NewLimiter(
-				rate.Limit(bandwidth),
-				64*1024, // add a small burst, otherwise it might fail
-			)
-		}
+		transportWrapper.Base = baseTransport
 
 		o.HTTPClient = &http.Client{
 			Transport: transportWrapper,
@@ -187,18 +296,44 @@ func New(ctx context.Context, cfg ControllerConfig) (*Controller, error) {
 	})
 
 	return &Controller{
-		ctx:       ctx,
-		OutWriter: cfg.OutWriter,
-		ErrWriter: cfg.ErrWriter,
-		verbosity: cfg.Verbosity,
-		client:    s3.NewFromConfig(awsCfg, clientOptions...),
+		ctx:                ctx,
+		OutWriter:          cfg.OutWriter,
+		ErrWriter:          cfg.ErrWriter,
+		inReader:           cfg.InReader,
+		interactive:        cfg.Interactive,
+		assumeYes:          cfg.AssumeYes,
+		confirmDestructive: cfg.Profile.ConfirmDestructive,
+		auditPath:          auditPath,
+		mimeTypes:          mimeTypes,
+		transport:          transportWrapper,
+		verbosity:          cfg.Verbosity,
+		client:             s3.NewFromConfig(awsCfg, clientOptions...),
 	}, nil
 }
 
+// readOnlyError is returned for requests blocked by the read-only mode.
+type readOnlyError struct{}
+
+func (readOnlyError) Error() string {
+	return "blocked by read-only mode"
+}
+
+// RetryableError prevents the SDK from retrying blocked requests,
+// while reads keep the configured retries.
+func (readOnlyError) RetryableError() bool {
+	return false
+}
+
 type TransportWrapper struct {
-	Base     http.RoundTripper
-	ReadOnly bool
-	Limiter  *rate.Limiter
+	Base            http.RoundTripper
+	ReadOnly        bool
+	UploadLimiter   *rate.Limiter
+	DownloadLimiter *rate.Limiter
+	RequestLimiter  *rate.Limiter
+	IdleReadTimeout time.Duration
+
+	requests      atomic.Int64
+	requestWaited atomic.Int64
 }
 
 func (t *TransportWrapper) RoundTrip(req *http.Request) (*http.Response, error) {
@@ -206,26 +341,51 @@ func (t *TransportWrapper) RoundTrip(req *http.Request) (*http.Response, error)
 		switch req.Method {
 		case http.MethodHead, http.MethodGet, http.MethodOptions, http.MethodTrace:
 		default:
-			return nil, fmt.Errorf("blocked by read-only mode")
+			return nil, readOnlyError{}
+		}
+	}
+
+	if t.RequestLimiter != nil {
+		start := time.Now()
+		if err := t.RequestLimiter.Wait(req.Context()); err != nil {
+			return nil, err
 		}
+		t.requests.Add(1)
+		t.requestWaited.Add(int64(time.Since(start)))
 	}
 
-	if req.Body != nil && t.Limiter != nil {
-		req.Body = io.NopCloser(ratelimiter.NewReader(req.Context(), req.Body, t.Limiter))
+	if req.Body != nil && t.UploadLimiter != nil {
+		req.Body = io.NopCloser(ratelimiter.NewReader(req.Context(), req.Body, t.UploadLimiter))
 	}
 
 	resp, err := t.Base.RoundTrip(req)
 	if err != nil {
-		return nil, err
+		return nil, wrapTLSError(err)
+	}
+
+	if resp.Body != nil && t.IdleReadTimeout > 0 {
+		resp.Body = idletimeout.NewReader(resp.Body, t.IdleReadTimeout)
 	}
 
-	if resp.Body != nil && t.Limiter != nil {
-		resp.Body = io.NopCloser(ratelimiter.NewReader(req.Context(), resp.Body, t.Limiter))
+	if resp.Body != nil && t.DownloadLimiter != nil {
+		resp.Body = io.NopCloser(ratelimiter.NewReader(req.Context(), resp.Body, t.DownloadLimiter))
 	}
 
 	return resp, nil
 }
 
+// PrintStats reports the time spent waiting on the request rate limiter.
+func (c *Controller) PrintStats() {
+	if c.verbosity < 2 || c.transport.RequestLimiter == nil {
+		return
+	}
+
+	fmt.Fprintf(c.OutWriter, "> waited %v in total on the request rate limiter for %d requests <\n",
+		time.Duration(c.transport.requestWaited.Load()).Round(time.Millisecond),
+		c.transport.requests.Load(),
+	)
+}
+
 type AddHeadersMiddleware struct {
 	Headers map[string]string
 }
@@ -1,15 +1,20 @@
 package controller
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"iter"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/dustin/go-humanize"
+	"github.com/sj14/sss/util"
 )
 
 func (c *Controller) PartsList(bucket, key, uploadID string, asJson bool) error {
@@ -71,3 +76,92 @@ func (c *Controller) partsList(bucket, key, uploadID string) iter.Seq2[types.Par
 		}
 	}
 }
+
+// PartUpload uploads the file as a part of the multipart upload and prints
+// the ETag of the part. The file path '-' reads the part from stdin.
+func (c *Controller) PartUpload(bucket, key, uploadID string, partNumber int32, filePath string) error {
+	if key == "" {
+		return fmt.Errorf("empty key")
+	}
+	if uploadID == "" {
+		return fmt.Errorf("empty upload ID")
+	}
+
+	var body io.ReadSeeker
+
+	if filePath == "-" {
+		// stdin is not seekable, but the request body has to be for signing
+		b, err := io.ReadAll(c.inReader)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(b)
+	} else {
+		f, err := os.Open(filePath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		body = f
+	}
+
+	resp, err := c.client.UploadPart(c.ctx, &s3.UploadPartInput{
+		Bucket:     aws.String(bucket),
+		Key:        aws.String(key),
+		UploadId:   aws.String(uploadID),
+		PartNumber: aws.Int32(partNumber),
+		Body:       body,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.OutWriter, "%s\n", aws.ToString(resp.ETag))
+
+	return nil
+}
+
+type PartUploadCopyConfig struct {
+	SrcBucket    string
+	SrcKey       string
+	SrcVersionID string
+	// Range of the source object, e.g. '0-1023' or 'bytes=0-1023'.
+	Range string
+}
+
+// PartUploadCopy copies the source object, or a range of it, as a part of
+// the multipart upload and prints the ETag of the part.
+func (c *Controller) PartUploadCopy(bucket, key, uploadID string, partNumber int32, cfg PartUploadCopyConfig) error {
+	if key == "" {
+		return fmt.Errorf("empty key")
+	}
+	if uploadID == "" {
+		return fmt.Errorf("empty upload ID")
+	}
+	if cfg.SrcBucket == "" {
+		cfg.SrcBucket = bucket
+	}
+
+	copyRange := cfg.Range
+	if copyRange != "" && !strings.HasPrefix(copyRange, "bytes=") {
+		copyRange = "bytes=" + copyRange
+	}
+
+	resp, err := c.client.UploadPartCopy(c.ctx, &s3.UploadPartCopyInput{
+		Bucket:          aws.String(bucket),
+		Key:             aws.String(key),
+		UploadId:        aws.String(uploadID),
+		PartNumber:      aws.Int32(partNumber),
+		CopySource:      aws.String(copySource(cfg.SrcBucket, cfg.SrcKey, cfg.SrcVersionID)),
+		CopySourceRange: util.NilIfZero(copyRange),
+	})
+	if err != nil {
+		return err
+	}
+
+	if resp.CopyPartResult != nil {
+		fmt.Fprintf(c.OutWriter, "%s\n", aws.ToString(resp.CopyPartResult.ETag))
+	}
+
+	return nil
+}
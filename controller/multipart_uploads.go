@@ -1,14 +1,19 @@
 package controller
 
 import (
+	"bytes"
+	"cmp"
 	"encoding/json"
 	"fmt"
 	"iter"
+	"os"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -21,10 +26,13 @@ func (c *Controller) MultipartUploadCreate(bucket, key string) error {
 		Bucket: &bucket,
 		Key:    &key,
 	})
+	if err != nil {
+		return err
+	}
 
 	fmt.Fprintf(c.OutWriter, "%s\n", *resp.UploadId)
 
-	return err
+	return nil
 }
 
 func (c *Controller) MultipartUploadsList(bucket, prefix, originalPrefix, delimiter string, asJson bool) error {
@@ -120,3 +128,71 @@ func (c *Controller) BucketMultipartUploadAbortAll(bucket string, dryRun bool, c
 
 	return eg.Wait()
 }
+
+// MultipartUploadComplete completes the multipart upload. Without a parts file,
+// all uploaded parts are listed and assembled in order.
+func (c *Controller) MultipartUploadComplete(bucket, key, uploadID, partsPath string) error {
+	if key == "" {
+		return fmt.Errorf("empty key")
+	}
+	if uploadID == "" {
+		return fmt.Errorf("empty upload ID")
+	}
+
+	var upload *types.CompletedMultipartUpload
+
+	if partsPath != "" {
+		pBytes, err := os.ReadFile(partsPath)
+		if err != nil {
+			return err
+		}
+
+		dec := json.NewDecoder(bytes.NewBuffer(pBytes))
+		dec.DisallowUnknownFields()
+
+		if err := dec.Decode(&upload); err != nil {
+			return fmt.Errorf("failed to unmarshal parts file: %w", err)
+		}
+	} else {
+		upload = &types.CompletedMultipartUpload{}
+
+		for part, err := range c.partsList(bucket, key, uploadID) {
+			if err != nil {
+				return err
+			}
+
+			upload.Parts = append(upload.Parts, types.CompletedPart{
+				PartNumber:        part.PartNumber,
+				ETag:              part.ETag,
+				ChecksumCRC32:     part.ChecksumCRC32,
+				ChecksumCRC32C:    part.ChecksumCRC32C,
+				ChecksumCRC64NVME: part.ChecksumCRC64NVME,
+				ChecksumSHA1:      part.ChecksumSHA1,
+				ChecksumSHA256:    part.ChecksumSHA256,
+			})
+		}
+
+		// parts are listed in order, but be safe with odd gateways
+		slices.SortFunc(upload.Parts, func(a, b types.CompletedPart) int {
+			return cmp.Compare(aws.ToInt32(a.PartNumber), aws.ToInt32(b.PartNumber))
+		})
+	}
+
+	if upload == nil || len(upload.Parts) == 0 {
+		return fmt.Errorf("no parts to complete the upload")
+	}
+
+	resp, err := c.client.CompleteMultipartUpload(c.ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(bucket),
+		Key:             aws.String(key),
+		UploadId:        aws.String(uploadID),
+		MultipartUpload: upload,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.OutWriter, "completed %s with %d parts (%s)\n", key, len(upload.Parts), aws.ToString(resp.ETag))
+
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/sj14/sss/util"
 )
 
@@ -19,7 +20,11 @@ func (c *Controller) ObjectACLGet(bucket, key, version string) error {
 		return err
 	}
 
-	b, err := json.MarshalIndent(resp, "", "  ")
+	// same format as accepted by 'acl put --file'
+	b, err := json.MarshalIndent(types.AccessControlPolicy{
+		Owner:  resp.Owner,
+		Grants: resp.Grants,
+	}, "", "  ")
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,106 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/sj14/sss/util"
+)
+
+type ACLPutConfig struct {
+	// Canned ACL, e.g. 'private' or 'public-read'.
+	Canned string
+	// FilePath to a JSON file with the access control policy (Owner and Grants).
+	FilePath string
+	// Grantees in the header format, e.g. 'id=...', 'emailAddress=...' or 'uri=...'.
+	GrantRead        []string
+	GrantFullControl []string
+}
+
+type ObjectACLPutConfig struct {
+	ACLPutConfig
+	Bucket      string
+	VersionID   string
+	Delimiter   string
+	Concurrency int
+	DryRun      bool
+}
+
+// ObjectACLPut sets the ACL of a single object or, when the key ends with
+// the delimiter, of all objects below the prefix.
+func (c *Controller) ObjectACLPut(key string, cfg ObjectACLPutConfig) error {
+	policy, err := cfg.ACLPutConfig.policy()
+	if err != nil {
+		return err
+	}
+
+	return c.objectEach(cfg.Bucket, key, cfg.VersionID, cfg.Delimiter, cfg.Concurrency, func(key string) error {
+		fmt.Fprintf(c.OutWriter, "setting ACL of %s\n", key)
+		if cfg.DryRun {
+			return nil
+		}
+
+		_, err := c.client.PutObjectAcl(c.ctx, &s3.PutObjectAclInput{
+			Bucket:              aws.String(cfg.Bucket),
+			Key:                 aws.String(key),
+			VersionId:           util.NilIfZero(cfg.VersionID),
+			ACL:                 types.ObjectCannedACL(cfg.Canned),
+			AccessControlPolicy: policy,
+			GrantRead:           grantHeader(cfg.GrantRead),
+			GrantFullControl:    grantHeader(cfg.GrantFullControl),
+		})
+		return err
+	})
+}
+
+// policy validates that exactly one kind of ACL is given and reads the
+// access control policy file when set.
+func (cfg ACLPutConfig) policy() (*types.AccessControlPolicy, error) {
+	var kinds int
+	for _, set := range []bool{
+		cfg.Canned != "",
+		cfg.FilePath != "",
+		len(cfg.GrantRead) > 0 || len(cfg.GrantFullControl) > 0,
+	} {
+		if set {
+			kinds++
+		}
+	}
+	if kinds == 0 {
+		return nil, errors.New("no ACL specified, use a canned ACL, a grant file or grant flags")
+	}
+	if kinds > 1 {
+		return nil, errors.New("canned ACL, grant file and grant flags can't be combined")
+	}
+
+	if cfg.FilePath == "" {
+		return nil, nil
+	}
+
+	aclBytes, err := os.ReadFile(cfg.FilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewBuffer(aclBytes))
+	dec.DisallowUnknownFields()
+
+	var policy *types.AccessControlPolicy
+	if err := dec.Decode(&policy); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ACL file: %w", err)
+	}
+
+	return policy, nil
+}
+
+// grantHeader joins the grantees into the format of the x-amz-grant-* headers.
+func grantHeader(grantees []string) *string {
+	return util.NilIfZero(strings.Join(grantees, ", "))
+}
@@ -1,33 +1,378 @@
 package controller
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
 	"path"
+	"slices"
+	"strings"
+	"sync/atomic"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/dustin/go-humanize"
 	"github.com/sj14/sss/util"
+	"github.com/sj14/sss/util/progress"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// maxCopyObjectSize is the largest source which can be copied with a single CopyObject request.
+	maxCopyObjectSize   = 5 * 1024 * 1024 * 1024
+	minCopyPartSize     = 5 * 1024 * 1024
+	maxCopyPartSize     = 5 * 1024 * 1024 * 1024
+	defaultCopyPartSize = 64 * 1024 * 1024
+	maxCopyParts        = 10_000
 )
 
 type ObjectCopyConfig struct {
-	SrcBucket string
-	SrcKey    string
-	DstBucket string
-	DstKey    string
-	SSEC      util.SSEC
+	SrcBucket    string
+	SrcKey       string
+	SrcVersionID string
+	DstBucket    string
+	DstKey       string
+	// SrcSSEC is the customer key of the source object.
+	SrcSSEC util.SSEC
+	// SSEC is the customer key of the destination object.
+	SSEC         util.SSEC
+	SSE          SSEConfig
+	StorageClass string
+	// Tags replace the tags of the source object when set.
+	Tags []string
+	// MetadataDirective is 'COPY' or 'REPLACE', defaults to 'REPLACE' when content headers or metadata are set.
+	MetadataDirective string
+	// TaggingDirective is 'COPY' or 'REPLACE', defaults to 'REPLACE' when tags are set.
+	TaggingDirective   string
+	ContentType        string
+	CacheControl       string
+	ContentDisposition string
+	ContentEncoding    string
+	ContentLanguage    string
+	// Metadata in the format 'key=value', sent as 'x-amz-meta-*' headers.
+	Metadata []string
+	// PartSize of multipart copies. Sources larger than 5 GiB, or larger
+	// than an explicitly set part size, are copied in parts.
+	PartSize    int64
+	Concurrency int
+	// Delimiter enables copying all objects below the source key when it ends with the delimiter.
+	Delimiter string
+	// Include and Exclude are glob patterns matched against the keys relative to the source prefix.
+	Include []string
+	Exclude []string
+	DryRun  bool
 }
 
+// ObjectCopy copies the object server-side. Sources which are too large for
+// a single CopyObject request are copied with concurrent UploadPartCopy requests.
+// When the source key ends with the delimiter, all objects below it are copied
+// into the destination prefix.
 func (c *Controller) ObjectCopy(cfg ObjectCopyConfig) error {
+	if cfg.SrcKey == "" {
+		return errors.New("missing source key")
+	}
 	if cfg.DstBucket == "" {
 		cfg.DstBucket = cfg.SrcBucket
 	}
+
+	if err := validateEncryption(cfg.SSE, cfg.SSEC); err != nil {
+		return err
+	}
+
+	if cfg.PartSize != 0 && cfg.PartSize < minCopyPartSize {
+		return fmt.Errorf("part size must be at least %d bytes", minCopyPartSize)
+	}
+	if cfg.PartSize > maxCopyPartSize {
+		return fmt.Errorf("part size must be at most %d bytes", maxCopyPartSize)
+	}
+
+	if _, _, err := cfg.directives(); err != nil {
+		return err
+	}
+
+	for _, pattern := range slices.Concat(cfg.Include, cfg.Exclude) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+	}
+
+	if cfg.Delimiter != "" && strings.HasSuffix(cfg.SrcKey, cfg.Delimiter) {
+		return c.objectCopyPrefix(cfg)
+	}
+
 	if cfg.DstKey == "" {
 		cfg.DstKey = cfg.SrcKey
 	}
 
+	if cfg.DryRun {
+		fmt.Fprintf(c.OutWriter, "copying %s to %s/%s\n", cfg.SrcKey, cfg.DstBucket, cfg.DstKey)
+		return nil
+	}
+
+	// the size is unknown without a listing
+	return c.objectCopyKey(cfg, -1)
+}
+
+// objectCopyPrefix copies all objects below the source prefix concurrently.
+func (c *Controller) objectCopyPrefix(cfg ObjectCopyConfig) error {
+	if cfg.SrcVersionID != "" {
+		return errors.New("version can't be used with a prefix")
+	}
+
+	srcPrefix := cfg.SrcKey
+	if srcPrefix == cfg.Delimiter {
+		// the whole bucket
+		srcPrefix = ""
+	}
+
+	dstPrefix := cfg.DstKey
+	switch {
+	case dstPrefix == "":
+		dstPrefix = srcPrefix
+	case dstPrefix == cfg.Delimiter:
+		dstPrefix = ""
+	case !strings.HasSuffix(dstPrefix, cfg.Delimiter):
+		return fmt.Errorf("destination %q has to end with the delimiter %q when copying a prefix", dstPrefix, cfg.Delimiter)
+	}
+
+	// copying an object onto itself is only allowed when something changes
+	metadataDirective, _, _ := cfg.directives()
+	if cfg.SrcBucket == cfg.DstBucket && srcPrefix == dstPrefix && metadataDirective == types.MetadataDirectiveCopy &&
+		cfg.StorageClass == "" && cfg.SSE.Algorithm == "" && !cfg.SSEC.KeyIsSet() {
+		return errors.New("source and destination are the same")
+	}
+
+	// don't copy the copies again when the destination is below the source
+	nested := cfg.SrcBucket == cfg.DstBucket && dstPrefix != srcPrefix && strings.HasPrefix(dstPrefix, srcPrefix)
+
+	var (
+		copied      atomic.Uint64
+		copiedBytes atomic.Uint64
+		skipped     atomic.Uint64
+	)
+
+	eg, _ := errgroup.WithContext(c.ctx)
+	eg.SetLimit(max(cfg.Concurrency, 1))
+
+	err := func() error {
+		for resp, err := range c.objectList(cfg.SrcBucket, srcPrefix, "") {
+			if err != nil {
+				return err
+			}
+
+			for _, obj := range resp.Contents {
+				key := aws.ToString(obj.Key)
+				relKey := strings.TrimPrefix(key, srcPrefix)
+
+				if (nested && strings.HasPrefix(key, dstPrefix)) || !matchFilters(relKey, cfg.Include, cfg.Exclude) {
+					skipped.Add(1)
+					continue
+				}
+
+				objCfg := cfg
+				objCfg.SrcKey = key
+				objCfg.DstKey = dstPrefix + relKey
+				// the objects are already copied concurrently, avoid C*C requests for large objects
+				objCfg.Concurrency = 1
+				size := uint64(aws.ToInt64(obj.Size))
+
+				eg.Go(func() error {
+					fmt.Fprintf(c.OutWriter, "copying %s to %s/%s (%s)\n", objCfg.SrcKey, objCfg.DstBucket, objCfg.DstKey, humanize.IBytes(size))
+					if !cfg.DryRun {
+						if err := c.objectCopyKey(objCfg, int64(size)); err != nil {
+							return fmt.Errorf("failed to copy %q: %w", objCfg.SrcKey, err)
+						}
+					}
+					copied.Add(1)
+					copiedBytes.Add(size)
+					return nil
+				})
+			}
+		}
+		return nil
+	}()
+
+	err = errors.Join(err, eg.Wait())
+
+	fmt.Fprintf(c.OutWriter, "copied objects: %d (%s) | skipped objects: %d\n",
+		copied.Load(),
+		humanize.IBytes(copiedBytes.Load()),
+		skipped.Load(),
+	)
+
+	return err
+}
+
+// matchFilters reports whether the key matches one of the include patterns,
+// if any, and none of the exclude patterns.
+func matchFilters(key string, include, exclude []string) bool {
+	for _, pattern := range exclude {
+		if ok, _ := path.Match(pattern, key); ok {
+			return false
+		}
+	}
+
+	if len(include) == 0 {
+		return true
+	}
+
+	for _, pattern := range include {
+		if ok, _ := path.Match(pattern, key); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// objectCopyKey copies a single object of the given size. The source object
+// is only looked up when the size is negative (unknown) or when its metadata
+// is required, i.e. for multipart copies and for keeping the content type.
+func (c *Controller) objectCopyKey(cfg ObjectCopyConfig, size int64) error {
+	metadataDirective, taggingDirective, err := cfg.directives()
+	if err != nil {
+		return err
+	}
+
+	multipart := func(size int64) bool {
+		return size > maxCopyObjectSize || (cfg.PartSize > 0 && size > cfg.PartSize)
+	}
+
+	var src *s3.HeadObjectOutput
+
+	if size < 0 || multipart(size) || (metadataDirective == types.MetadataDirectiveReplace && cfg.ContentType == "") {
+		src, err = c.headCopySource(cfg)
+		if err != nil {
+			return err
+		}
+		size = aws.ToInt64(src.ContentLength)
+	}
+
+	if multipart(size) {
+		return c.objectCopyMultipart(cfg, src, metadataDirective, taggingDirective)
+	}
+
+	return c.objectCopy(cfg, src, metadataDirective, taggingDirective)
+}
+
+func (c *Controller) headCopySource(cfg ObjectCopyConfig) (*s3.HeadObjectOutput, error) {
+	headInput := &s3.HeadObjectInput{
+		Bucket:    aws.String(cfg.SrcBucket),
+		Key:       aws.String(cfg.SrcKey),
+		VersionId: util.NilIfZero(cfg.SrcVersionID),
+	}
+	if cfg.SrcSSEC.KeyIsSet() {
+		headInput.SSECustomerAlgorithm = aws.String(cfg.SrcSSEC.Algorithm())
+		headInput.SSECustomerKey = aws.String(cfg.SrcSSEC.Base64Key())
+		headInput.SSECustomerKeyMD5 = aws.String(cfg.SrcSSEC.Base64KeyMD5())
+	}
+
+	src, err := c.client.HeadObject(c.ctx, headInput)
+	if err != nil {
+		return nil, fmt.Errorf("failed to head source object: %w", err)
+	}
+
+	return src, nil
+}
+
+func (cfg ObjectCopyConfig) directives() (types.MetadataDirective, types.TaggingDirective, error) {
+	metadataDirective := types.MetadataDirective(strings.ToUpper(cfg.MetadataDirective))
+	if metadataDirective != "" && !slices.Contains(metadataDirective.Values(), metadataDirective) {
+		return "", "", fmt.Errorf("invalid metadata directive %q (use one of %v)", cfg.MetadataDirective, metadataDirective.Values())
+	}
+
+	contentSet := cfg.ContentType != "" || cfg.CacheControl != "" || cfg.ContentDisposition != "" ||
+		cfg.ContentEncoding != "" || cfg.ContentLanguage != "" || len(cfg.Metadata) > 0
+
+	switch {
+	case metadataDirective == "" && contentSet:
+		metadataDirective = types.MetadataDirectiveReplace
+	case metadataDirective == "":
+		metadataDirective = types.MetadataDirectiveCopy
+	case metadataDirective == types.MetadataDirectiveCopy && contentSet:
+		return "", "", errors.New("content headers and metadata can't be combined with the COPY metadata directive")
+	}
+
+	taggingDirective := types.TaggingDirective(strings.ToUpper(cfg.TaggingDirective))
+	if taggingDirective != "" && !slices.Contains(taggingDirective.Values(), taggingDirective) {
+		return "", "", fmt.Errorf("invalid tagging directive %q (use one of %v)", cfg.TaggingDirective, taggingDirective.Values())
+	}
+
+	switch {
+	case taggingDirective == "" && len(cfg.Tags) > 0:
+		taggingDirective = types.TaggingDirectiveReplace
+	case taggingDirective == "":
+		taggingDirective = types.TaggingDirectiveCopy
+	case taggingDirective == types.TaggingDirectiveCopy && len(cfg.Tags) > 0:
+		return "", "", errors.New("tags can't be combined with the COPY tagging directive")
+	}
+
+	return metadataDirective, taggingDirective, nil
+}
+
+func (cfg ObjectCopyConfig) copySource() string {
+	return copySource(cfg.SrcBucket, cfg.SrcKey, cfg.SrcVersionID)
+}
+
+// copySource returns the x-amz-copy-source value of the object. The key is
+// URL-encoded segment by segment. A plus sign is escaped as well, as some
+// servers decode it as a space.
+func copySource(bucket, key, versionID string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = strings.ReplaceAll(url.PathEscape(segment), "+", "%2B")
+	}
+
+	source := bucket + "/" + strings.Join(segments, "/")
+	if versionID != "" {
+		source += "?versionId=" + url.QueryEscape(versionID)
+	}
+	return source
+}
+
+// objectCopy copies the object with a single CopyObject request.
+func (c *Controller) objectCopy(cfg ObjectCopyConfig, src *s3.HeadObjectOutput, metadataDirective types.MetadataDirective, taggingDirective types.TaggingDirective) error {
 	input := &s3.CopyObjectInput{
-		Bucket:     aws.String(cfg.DstBucket),
-		CopySource: aws.String(path.Join(cfg.SrcBucket, cfg.SrcKey)),
-		Key:        aws.String(cfg.DstKey),
+		Bucket:            aws.String(cfg.DstBucket),
+		CopySource:        aws.String(cfg.copySource()),
+		Key:               aws.String(cfg.DstKey),
+		StorageClass:      types.StorageClass(cfg.StorageClass),
+		MetadataDirective: metadataDirective,
+		TaggingDirective:  taggingDirective,
+	}
+
+	if metadataDirective == types.MetadataDirectiveReplace {
+		metadata, err := parseMetadata(cfg.Metadata)
+		if err != nil {
+			return err
+		}
+
+		input.ContentType = util.NilIfZero(cfg.ContentType)
+		if input.ContentType == nil {
+			// keep the content type, S3 would fall back to 'binary/octet-stream'
+			input.ContentType = src.ContentType
+		}
+		input.CacheControl = util.NilIfZero(cfg.CacheControl)
+		input.ContentDisposition = util.NilIfZero(cfg.ContentDisposition)
+		input.ContentEncoding = util.NilIfZero(cfg.ContentEncoding)
+		input.ContentLanguage = util.NilIfZero(cfg.ContentLanguage)
+		input.Metadata = metadata
+	}
+
+	if taggingDirective == types.TaggingDirectiveReplace {
+		tagging, err := tagsQuery(cfg.Tags)
+		if err != nil {
+			return err
+		}
+		input.Tagging = tagging
+	}
+
+	if cfg.SSE.Algorithm != "" {
+		input.ServerSideEncryption = types.ServerSideEncryption(cfg.SSE.Algorithm)
+		input.SSEKMSKeyId = util.NilIfZero(cfg.SSE.KMSKeyID)
+		input.BucketKeyEnabled = util.NilIfZero(cfg.SSE.BucketKey)
 	}
 
 	if cfg.SSEC.KeyIsSet() {
@@ -36,6 +381,12 @@ func (c *Controller) ObjectCopy(cfg ObjectCopyConfig) error {
 		input.SSECustomerKeyMD5 = aws.String(cfg.SSEC.Base64KeyMD5())
 	}
 
+	if cfg.SrcSSEC.KeyIsSet() {
+		input.CopySourceSSECustomerAlgorithm = aws.String(cfg.SrcSSEC.Algorithm())
+		input.CopySourceSSECustomerKey = aws.String(cfg.SrcSSEC.Base64Key())
+		input.CopySourceSSECustomerKeyMD5 = aws.String(cfg.SrcSSEC.Base64KeyMD5())
+	}
+
 	_, err := c.client.CopyObject(c.ctx, input)
 	if err != nil {
 		return err
@@ -43,3 +394,185 @@ func (c *Controller) ObjectCopy(cfg ObjectCopyConfig) error {
 
 	return nil
 }
+
+// objectCopyMultipart copies the object in parts. Unlike CopyObject, a multipart
+// upload doesn't take over the metadata and tags of the source, so they are
+// applied from the source object when the directive is COPY.
+func (c *Controller) objectCopyMultipart(cfg ObjectCopyConfig, src *s3.HeadObjectOutput, metadataDirective types.MetadataDirective, taggingDirective types.TaggingDirective) error {
+	size := aws.ToInt64(src.ContentLength)
+
+	partSize := cfg.PartSize
+	if partSize == 0 {
+		partSize = defaultCopyPartSize
+	}
+	if minPartSize := (size + maxCopyParts - 1) / maxCopyParts; partSize < minPartSize {
+		if minPartSize > maxCopyPartSize {
+			return fmt.Errorf("object of %s is too large to copy in at most %d parts", humanize.IBytes(uint64(size)), maxCopyParts)
+		}
+		partSize = minPartSize
+	}
+
+	createInput := &s3.CreateMultipartUploadInput{
+		Bucket:       aws.String(cfg.DstBucket),
+		Key:          aws.String(cfg.DstKey),
+		StorageClass: types.StorageClass(cfg.StorageClass),
+	}
+
+	switch metadataDirective {
+	case types.MetadataDirectiveReplace:
+		metadata, err := parseMetadata(cfg.Metadata)
+		if err != nil {
+			return err
+		}
+
+		createInput.ContentType = util.NilIfZero(cfg.ContentType)
+		if createInput.ContentType == nil {
+			createInput.ContentType = src.ContentType
+		}
+		createInput.CacheControl = util.NilIfZero(cfg.CacheControl)
+		createInput.ContentDisposition = util.NilIfZero(cfg.ContentDisposition)
+		createInput.ContentEncoding = util.NilIfZero(cfg.ContentEncoding)
+		createInput.ContentLanguage = util.NilIfZero(cfg.ContentLanguage)
+		createInput.Metadata = metadata
+	default:
+		createInput.ContentType = src.ContentType
+		createInput.CacheControl = src.CacheControl
+		createInput.ContentDisposition = src.ContentDisposition
+		createInput.ContentEncoding = src.ContentEncoding
+		createInput.ContentLanguage = src.ContentLanguage
+		createInput.Metadata = src.Metadata
+	}
+
+	switch taggingDirective {
+	case types.TaggingDirectiveReplace:
+		tagging, err := tagsQuery(cfg.Tags)
+		if err != nil {
+			return err
+		}
+		createInput.Tagging = tagging
+	default:
+		if aws.ToInt32(src.TagCount) > 0 {
+			tags, err := c.objectTags(cfg.SrcBucket, cfg.SrcKey, cfg.SrcVersionID)
+			if err != nil {
+				return fmt.Errorf("failed to get tags of source object: %w", err)
+			}
+			createInput.Tagging = encodeTags(tags)
+		}
+	}
+
+	if cfg.SSE.Algorithm != "" {
+		createInput.ServerSideEncryption = types.ServerSideEncryption(cfg.SSE.Algorithm)
+		createInput.SSEKMSKeyId = util.NilIfZero(cfg.SSE.KMSKeyID)
+		createInput.BucketKeyEnabled = util.NilIfZero(cfg.SSE.BucketKey)
+	}
+
+	if cfg.SSEC.KeyIsSet() {
+		createInput.SSECustomerAlgorithm = aws.String(cfg.SSEC.Algorithm())
+		createInput.SSECustomerKey = aws.String(cfg.SSEC.Base64Key())
+		createInput.SSECustomerKeyMD5 = aws.String(cfg.SSEC.Base64KeyMD5())
+	}
+
+	upload, err := c.client.CreateMultipartUpload(c.ctx, createInput)
+	if err != nil {
+		return err
+	}
+
+	err = c.objectCopyParts(cfg, upload.UploadId, size, partSize)
+	if err != nil {
+		// don't leave the copied parts behind, even when cancelled
+		_, abortErr := c.client.AbortMultipartUpload(context.WithoutCancel(c.ctx), &s3.AbortMultipartUploadInput{
+			Bucket:   aws.String(cfg.DstBucket),
+			Key:      aws.String(cfg.DstKey),
+			UploadId: upload.UploadId,
+		})
+		return errors.Join(err, abortErr)
+	}
+
+	return nil
+}
+
+func (c *Controller) objectCopyParts(cfg ObjectCopyConfig, uploadID *string, size, partSize int64) error {
+	var (
+		partsCount = (size + partSize - 1) / partSize
+		parts      = make([]types.CompletedPart, partsCount)
+		counter    = progress.NewCounter(c.OutWriter, uint64(size), c.verbosity, cfg.DstKey)
+	)
+	// also close the progress line on errors, which are printed afterwards
+	defer counter.Finish()
+
+	eg, ctx := errgroup.WithContext(c.ctx)
+	eg.SetLimit(max(cfg.Concurrency, 1))
+
+	for i := range partsCount {
+		start := i * partSize
+		end := min(start+partSize, size) - 1
+
+		eg.Go(func() error {
+			input := &s3.UploadPartCopyInput{
+				Bucket:          aws.String(cfg.DstBucket),
+				Key:             aws.String(cfg.DstKey),
+				UploadId:        uploadID,
+				PartNumber:      aws.Int32(int32(i + 1)),
+				CopySource:      aws.String(cfg.copySource()),
+				CopySourceRange: aws.String(fmt.Sprintf("bytes=%d-%d", start, end)),
+			}
+
+			if cfg.SSEC.KeyIsSet() {
+				input.SSECustomerAlgorithm = aws.String(cfg.SSEC.Algorithm())
+				input.SSECustomerKey = aws.String(cfg.SSEC.Base64Key())
+				input.SSECustomerKeyMD5 = aws.String(cfg.SSEC.Base64KeyMD5())
+			}
+
+			if cfg.SrcSSEC.KeyIsSet() {
+				input.CopySourceSSECustomerAlgorithm = aws.String(cfg.SrcSSEC.Algorithm())
+				input.CopySourceSSECustomerKey = aws.String(cfg.SrcSSEC.Base64Key())
+				input.CopySourceSSECustomerKeyMD5 = aws.String(cfg.SrcSSEC.Base64KeyMD5())
+			}
+
+			resp, err := c.client.UploadPartCopy(ctx, input)
+			if err != nil {
+				return fmt.Errorf("failed to copy part %d: %w", i+1, err)
+			}
+
+			parts[i] = types.CompletedPart{
+				PartNumber: aws.Int32(int32(i + 1)),
+			}
+			if resp.CopyPartResult != nil {
+				parts[i].ETag = resp.CopyPartResult.ETag
+				parts[i].ChecksumCRC32 = resp.CopyPartResult.ChecksumCRC32
+				parts[i].ChecksumCRC32C = resp.CopyPartResult.ChecksumCRC32C
+				parts[i].ChecksumCRC64NVME = resp.CopyPartResult.ChecksumCRC64NVME
+				parts[i].ChecksumSHA1 = resp.CopyPartResult.ChecksumSHA1
+				parts[i].ChecksumSHA256 = resp.CopyPartResult.ChecksumSHA256
+			}
+
+			counter.Add(uint64(end - start + 1))
+
+			return nil
+		})
+	}
+
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	completeInput := &s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(cfg.DstBucket),
+		Key:             aws.String(cfg.DstKey),
+		UploadId:        uploadID,
+		MultipartUpload: &types.CompletedMultipartUpload{Parts: parts},
+	}
+
+	if cfg.SSEC.KeyIsSet() {
+		completeInput.SSECustomerAlgorithm = aws.String(cfg.SSEC.Algorithm())
+		completeInput.SSECustomerKey = aws.String(cfg.SSEC.Base64Key())
+		completeInput.SSECustomerKeyMD5 = aws.String(cfg.SSEC.Base64KeyMD5())
+	}
+
+	_, err := c.client.CompleteMultipartUpload(c.ctx, completeInput)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/shoenig/test/must"
+)
+
+func TestObjectCopyDirectives(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		cfg          ObjectCopyConfig
+		wantMetadata types.MetadataDirective
+		wantTagging  types.TaggingDirective
+		wantErr      bool
+	}{
+		{
+			name:         "defaults",
+			wantMetadata: types.MetadataDirectiveCopy,
+			wantTagging:  types.TaggingDirectiveCopy,
+		},
+		{
+			name:         "content type implies replace",
+			cfg:          ObjectCopyConfig{ContentType: "text/plain"},
+			wantMetadata: types.MetadataDirectiveReplace,
+			wantTagging:  types.TaggingDirectiveCopy,
+		},
+		{
+			name:         "metadata implies replace",
+			cfg:          ObjectCopyConfig{Metadata: []string{"a=b"}},
+			wantMetadata: types.MetadataDirectiveReplace,
+			wantTagging:  types.TaggingDirectiveCopy,
+		},
+		{
+			name:         "tags imply replace",
+			cfg:          ObjectCopyConfig{Tags: []string{"a=b"}},
+			wantMetadata: types.MetadataDirectiveCopy,
+			wantTagging:  types.TaggingDirectiveReplace,
+		},
+		{
+			name:         "explicit replace",
+			cfg:          ObjectCopyConfig{MetadataDirective: "REPLACE", TaggingDirective: "REPLACE"},
+			wantMetadata: types.MetadataDirectiveReplace,
+			wantTagging:  types.TaggingDirectiveReplace,
+		},
+		{
+			name:         "lowercase",
+			cfg:          ObjectCopyConfig{MetadataDirective: "replace", TaggingDirective: "copy"},
+			wantMetadata: types.MetadataDirectiveReplace,
+			wantTagging:  types.TaggingDirectiveCopy,
+		},
+		{
+			name:    "invalid metadata directive",
+			cfg:     ObjectCopyConfig{MetadataDirective: "MOVE"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid tagging directive",
+			cfg:     ObjectCopyConfig{TaggingDirective: "MOVE"},
+			wantErr: true,
+		},
+		{
+			name:    "copy with content headers",
+			cfg:     ObjectCopyConfig{MetadataDirective: "COPY", CacheControl: "no-cache"},
+			wantErr: true,
+		},
+		{
+			name:    "copy with tags",
+			cfg:     ObjectCopyConfig{TaggingDirective: "COPY", Tags: []string{"a=b"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metadata, tagging, err := tt.cfg.directives()
+			if tt.wantErr {
+				must.Error(t, err)
+				return
+			}
+			must.NoError(t, err)
+			must.Eq(t, tt.wantMetadata, metadata)
+			must.Eq(t, tt.wantTagging, tagging)
+		})
+	}
+}
+
+func TestMatchFilters(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		key     string
+		include []string
+		exclude []string
+		want    bool
+	}{
+		{name: "no filters", key: "a.txt", want: true},
+		{name: "include match", key: "a.txt", include: []string{"*.txt"}, want: true},
+		{name: "include no match", key: "a.log", include: []string{"*.txt"}, want: false},
+		{name: "any include", key: "a.log", include: []string{"*.txt", "*.log"}, want: true},
+		{name: "exclude match", key: "a.log", exclude: []string{"*.log"}, want: false},
+		{name: "exclude no match", key: "a.txt", exclude: []string{"*.log"}, want: true},
+		{name: "exclude wins", key: "a.txt", include: []string{"*.txt"}, exclude: []string{"a.*"}, want: false},
+		{name: "nested", key: "sub/c.log", include: []string{"sub/*"}, want: true},
+		{name: "star stops at slash", key: "sub/c.log", include: []string{"*.log"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			must.Eq(t, tt.want, matchFilters(tt.key, tt.include, tt.exclude))
+		})
+	}
+}
@@ -3,7 +3,9 @@ package controller
 import (
 	"errors"
 	"fmt"
+	"path"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
@@ -20,6 +22,12 @@ type ObjectDeleteConfig struct {
 	DryRun           bool
 	BypassGovernance bool
 	VersionID        string
+	// Confirmed skips the confirmation prompt, e.g. when the caller already asked.
+	Confirmed bool
+	// Trash copies objects to the trash prefix before deleting them.
+	Trash bool
+
+	trashDir string
 }
 
 // TODO:
@@ -31,6 +39,30 @@ func (c *Controller) ObjectDelete(prefix string, cfg ObjectDeleteConfig) error {
 	if prefix == "/" && !cfg.Force && !cfg.DryRun {
 		return errors.New("use -force flag to empty the whole bucket")
 	}
+	if cfg.Trash && cfg.VersionID != "" {
+		return errors.New("trash can't be used for deleting specific versions")
+	}
+	if cfg.Trash && cfg.trashDir == "" {
+		// all objects of one deletion share the same trash directory
+		cfg.trashDir = path.Join(trashPrefix, time.Now().UTC().Format(trashTimeFormat))
+	}
+	// confirm bulk deletions once, nested prefixes are deleted with the same config
+	if strings.HasSuffix(prefix, cfg.Delimiter) && !cfg.DryRun && !cfg.Confirmed {
+		listPrefix := prefix
+		if prefix == "/" {
+			listPrefix = ""
+		}
+		err := c.confirmDelete(cfg.Bucket, listPrefix, false, func(preview string) string {
+			if prefix == "/" {
+				return fmt.Sprintf("Deleting all %s from bucket %q.", preview, cfg.Bucket)
+			}
+			return fmt.Sprintf("Deleting %s below %q from bucket %q.", preview, prefix, cfg.Bucket)
+		})
+		if err != nil {
+			return err
+		}
+		cfg.Confirmed = true
+	}
 
 	// only delete single object
 	if !strings.HasSuffix(prefix, cfg.Delimiter) {
@@ -43,7 +75,7 @@ func (c *Controller) ObjectDelete(prefix string, cfg ObjectDeleteConfig) error {
 		} else {
 			fmt.Fprintf(c.OutWriter, "deleting %s (%s)\n", prefix, humanize.IBytes(uint64(*resp.ContentLength)))
 		}
-		return c.objectDelete(cfg.DryRun, cfg.BypassGovernance, cfg.Bucket, prefix, cfg.VersionID)
+		return c.objectTrashOrDelete(cfg, prefix)
 	}
 
 	// allow deleting the whole bucket
@@ -61,6 +93,10 @@ func (c *Controller) ObjectDelete(prefix string, cfg ObjectDeleteConfig) error {
 		}
 
 		for _, l := range l.CommonPrefixes {
+			// keep the trash when moving everything into it
+			if cfg.Trash && isTrashKey(*l.Prefix) {
+				continue
+			}
 			err := c.ObjectDelete(*l.Prefix, cfg)
 			if err != nil {
 				return err
@@ -68,9 +104,12 @@ func (c *Controller) ObjectDelete(prefix string, cfg ObjectDeleteConfig) error {
 		}
 
 		for _, l := range l.Contents {
+			if cfg.Trash && isTrashKey(*l.Key) {
+				continue
+			}
 			eg.Go(func() error {
 				fmt.Fprintf(c.OutWriter, "deleting %s (%s)\n", *l.Key, humanize.IBytes(uint64(*l.Size)))
-				err := c.objectDelete(cfg.DryRun, cfg.BypassGovernance, cfg.Bucket, *l.Key, cfg.VersionID)
+				err := c.objectTrashOrDelete(cfg, *l.Key)
 				if err != nil {
 					return err
 				}
@@ -82,6 +121,24 @@ func (c *Controller) ObjectDelete(prefix string, cfg ObjectDeleteConfig) error {
 	return eg.Wait()
 }
 
+// objectTrashOrDelete moves the object into the trash when enabled,
+// objects which are already in the trash are deleted permanently.
+func (c *Controller) objectTrashOrDelete(cfg ObjectDeleteConfig, key string) error {
+	if cfg.Trash && !isTrashKey(key) && !cfg.DryRun {
+		err := c.ObjectCopy(ObjectCopyConfig{
+			SrcBucket: cfg.Bucket,
+			SrcKey:    key,
+			// no path.Join, which would clean the key (e.g. 'docs/' or 'a//b')
+			DstKey: cfg.trashDir + "/" + key,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to move %q to trash: %w", key, err)
+		}
+	}
+
+	return c.objectDelete(cfg.DryRun, cfg.BypassGovernance, cfg.Bucket, key, cfg.VersionID)
+}
+
 func (c *Controller) objectDelete(dryRun, bypassGovernanceRetention bool, bucket, key, versionID string) error {
 	if dryRun {
 		return nil
@@ -30,7 +30,15 @@ func (c *Controller) ObjectHead(bucket, key string, cfg ObjectHeadConfig) error
 		return err
 	}
 
-	b, err := json.MarshalIndent(resp, "", "  ")
+	b, err := json.MarshalIndent(struct {
+		*s3.HeadObjectOutput
+		Encryption    string
+		RestoreStatus string `json:",omitempty"`
+	}{
+		HeadObjectOutput: resp,
+		Encryption:       describeEncryption(resp.ServerSideEncryption, resp.SSEKMSKeyId, resp.BucketKeyEnabled, resp.SSECustomerAlgorithm),
+		RestoreStatus:    describeRestore(resp.Restore),
+	}, "", "  ")
 	if err != nil {
 		return err
 	}
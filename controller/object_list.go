@@ -2,6 +2,7 @@ package controller
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"iter"
 	"strings"
@@ -10,6 +11,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/dustin/go-humanize"
+	"golang.org/x/sync/errgroup"
 )
 
 func (c *Controller) ObjectList(bucket, prefix, originalPrefix, delimiter string, asJson bool) error {
@@ -19,7 +21,7 @@ func (c *Controller) ObjectList(bucket, prefix, originalPrefix, delimiter string
 		}
 
 		for _, prefix := range l.CommonPrefixes {
-			fmt.Fprintf(c.OutWriter, "%28s  %s\n", "PREFIX", *prefix.Prefix)
+			fmt.Fprintf(c.OutWriter, "%42s  %s\n", "PREFIX", *prefix.Prefix)
 		}
 
 		for _, object := range l.Contents {
@@ -31,9 +33,14 @@ func (c *Controller) ObjectList(bucket, prefix, originalPrefix, delimiter string
 				fmt.Println(string(b))
 				continue
 			}
-			fmt.Fprintf(c.OutWriter, "%s %8s  %s\n",
+			storageClass := string(object.StorageClass)
+			if storageClass == "" {
+				storageClass = "-"
+			}
+			fmt.Fprintf(c.OutWriter, "%s %8s  %-12s  %s\n",
 				object.LastModified.Local().Format(time.DateTime),
 				humanize.IBytes(uint64(*object.Size)),
+				storageClass,
 				strings.TrimPrefix(*object.Key, originalPrefix),
 			)
 		}
@@ -59,3 +66,40 @@ func (c *Controller) objectList(bucket, prefix, delimiter string) iter.Seq2[*s3.
 		}
 	}
 }
+
+// objectEach calls fn for the key or, when the key ends with the
+// delimiter, concurrently for all objects below the prefix.
+func (c *Controller) objectEach(bucket, key, versionID, delimiter string, concurrency int, fn func(key string) error) error {
+	if key == "" {
+		return errors.New("missing key")
+	}
+
+	if !strings.HasSuffix(key, delimiter) {
+		return fn(key)
+	}
+
+	if versionID != "" {
+		return errors.New("version can't be used with a prefix")
+	}
+
+	if key == "/" {
+		key = ""
+	}
+
+	eg, _ := errgroup.WithContext(c.ctx)
+	eg.SetLimit(concurrency)
+
+	for l, err := range c.objectList(bucket, key, "") {
+		if err != nil {
+			return err
+		}
+
+		for _, obj := range l.Contents {
+			eg.Go(func() error {
+				return fn(*obj.Key)
+			})
+		}
+	}
+
+	return eg.Wait()
+}
@@ -19,6 +19,7 @@ import (
 type ObjectPutConfig struct {
 	Bucket            string
 	SSEC              util.SSEC
+	SSE               SSEConfig
 	Concurrency       int
 	LeavePartsOnError bool
 	MaxUploadParts    int32
@@ -26,6 +27,16 @@ type ObjectPutConfig struct {
 	ACL               string
 	DryRun            bool
 	Expires           time.Time
+	Tags              []string
+	StorageClass      string
+	// ContentType overrides the detected content type.
+	ContentType        string
+	CacheControl       string
+	ContentDisposition string
+	ContentEncoding    string
+	ContentLanguage    string
+	// Metadata in the format 'key=value', sent as 'x-amz-meta-*' headers.
+	Metadata []string
 }
 
 func (c *Controller) ObjectPut(filePath, dest string, cfg ObjectPutConfig) error {
@@ -84,6 +95,25 @@ func (c *Controller) ObjectPut(filePath, dest string, cfg ObjectPutConfig) error
 }
 
 func (c *Controller) objectPut(body io.Reader, size uint64, key string, cfg ObjectPutConfig) error {
+	if err := validateEncryption(cfg.SSE, cfg.SSEC); err != nil {
+		return err
+	}
+
+	tagging, err := tagsQuery(cfg.Tags)
+	if err != nil {
+		return err
+	}
+
+	metadata, err := parseMetadata(cfg.Metadata)
+	if err != nil {
+		return err
+	}
+
+	contentType := cfg.ContentType
+	if contentType == "" {
+		contentType, body = c.detectContentType(key, body)
+	}
+
 	uploader := manager.NewUploader(c.client, func(u *manager.Uploader) {
 		u.Concurrency = cfg.Concurrency
 		u.LeavePartsOnError = cfg.LeavePartsOnError
@@ -95,11 +125,25 @@ func (c *Controller) objectPut(body io.Reader, size uint64, key string, cfg Obje
 	pr := progress.NewReader(c.OutWriter, body, size, c.verbosity, key)
 
 	putObjectInput := &s3.PutObjectInput{
-		Bucket:  aws.String(cfg.Bucket),
-		Key:     aws.String(filepath.ToSlash(filepath.Clean(key))),
-		Body:    pr,
-		ACL:     types.ObjectCannedACL(cfg.ACL),
-		Expires: aws.Time(cfg.Expires),
+		Bucket:             aws.String(cfg.Bucket),
+		Key:                aws.String(filepath.ToSlash(filepath.Clean(key))),
+		Body:               pr,
+		ACL:                types.ObjectCannedACL(cfg.ACL),
+		Expires:            aws.Time(cfg.Expires),
+		Tagging:            tagging,
+		StorageClass:       types.StorageClass(cfg.StorageClass),
+		ContentType:        aws.String(contentType),
+		CacheControl:       util.NilIfZero(cfg.CacheControl),
+		ContentDisposition: util.NilIfZero(cfg.ContentDisposition),
+		ContentEncoding:    util.NilIfZero(cfg.ContentEncoding),
+		ContentLanguage:    util.NilIfZero(cfg.ContentLanguage),
+		Metadata:           metadata,
+	}
+
+	if cfg.SSE.Algorithm != "" {
+		putObjectInput.ServerSideEncryption = types.ServerSideEncryption(cfg.SSE.Algorithm)
+		putObjectInput.SSEKMSKeyId = util.NilIfZero(cfg.SSE.KMSKeyID)
+		putObjectInput.BucketKeyEnabled = util.NilIfZero(cfg.SSE.BucketKey)
 	}
 
 	if cfg.SSEC.KeyIsSet() {
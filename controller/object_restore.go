@@ -0,0 +1,178 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+	"github.com/sj14/sss/util"
+)
+
+type ObjectRestoreConfig struct {
+	Bucket      string
+	VersionID   string
+	Delimiter   string
+	Concurrency int
+	DryRun      bool
+	Days        int32
+	// Tier is 'Standard', 'Bulk' or 'Expedited'.
+	Tier string
+	// Wait polls with the given interval until all restores are completed.
+	Wait         bool
+	PollInterval time.Duration
+}
+
+// ObjectRestore restores archived objects (e.g. GLACIER or DEEP_ARCHIVE)
+// temporarily for the given number of days. Objects below a prefix which
+// are not archived are skipped.
+func (c *Controller) ObjectRestore(key string, cfg ObjectRestoreConfig) error {
+	if cfg.Days < 1 {
+		return errors.New("days must be at least 1")
+	}
+
+	tier := types.Tier(cfg.Tier)
+	if cfg.Tier != "" && !slices.Contains(tier.Values(), tier) {
+		return fmt.Errorf("invalid tier %q (use one of %v)", cfg.Tier, tier.Values())
+	}
+
+	var (
+		// objects which are not archived are only skipped when restoring a prefix
+		recursive = strings.HasSuffix(key, cfg.Delimiter)
+		mu        sync.Mutex
+		pending   []string
+	)
+
+	err := c.objectEach(cfg.Bucket, key, cfg.VersionID, cfg.Delimiter, cfg.Concurrency, func(key string) error {
+		if cfg.DryRun {
+			fmt.Fprintf(c.OutWriter, "restoring %s for %d days\n", key, cfg.Days)
+			return nil
+		}
+
+		input := &s3.RestoreObjectInput{
+			Bucket:    aws.String(cfg.Bucket),
+			Key:       aws.String(key),
+			VersionId: util.NilIfZero(cfg.VersionID),
+			RestoreRequest: &types.RestoreRequest{
+				Days: aws.Int32(cfg.Days),
+			},
+		}
+		if tier != "" {
+			input.RestoreRequest.GlacierJobParameters = &types.GlacierJobParameters{Tier: tier}
+		}
+
+		_, err := c.client.RestoreObject(c.ctx, input)
+
+		var apiErr smithy.APIError
+		switch {
+		case err == nil:
+			fmt.Fprintf(c.OutWriter, "restoring %s for %d days\n", key, cfg.Days)
+		case errors.As(err, &apiErr) && apiErr.ErrorCode() == "RestoreAlreadyInProgress":
+			fmt.Fprintf(c.OutWriter, "restore of %s already in progress\n", key)
+		case recursive && errors.As(err, &apiErr) && apiErr.ErrorCode() == "InvalidObjectState":
+			fmt.Fprintf(c.OutWriter, "skipping %s, not archived\n", key)
+			return nil
+		default:
+			return fmt.Errorf("failed to restore %q: %w", key, err)
+		}
+
+		mu.Lock()
+		pending = append(pending, key)
+		mu.Unlock()
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if !cfg.Wait || len(pending) == 0 {
+		return nil
+	}
+
+	return c.objectRestoreWait(cfg, pending)
+}
+
+// objectRestoreWait polls the restore status until all objects are restored.
+func (c *Controller) objectRestoreWait(cfg ObjectRestoreConfig, keys []string) error {
+	ticker := time.NewTicker(cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		var remaining []string
+
+		for _, key := range keys {
+			resp, err := c.client.HeadObject(c.ctx, &s3.HeadObjectInput{
+				Bucket:    aws.String(cfg.Bucket),
+				Key:       aws.String(key),
+				VersionId: util.NilIfZero(cfg.VersionID),
+			})
+			if err != nil {
+				return err
+			}
+
+			ongoing, _ := parseRestoreStatus(aws.ToString(resp.Restore))
+			if ongoing {
+				remaining = append(remaining, key)
+				continue
+			}
+			fmt.Fprintf(c.OutWriter, "restored %s\n", key)
+		}
+
+		if len(remaining) == 0 {
+			return nil
+		}
+		keys = remaining
+
+		fmt.Fprintf(c.OutWriter, "waiting for %d restores\n", len(keys))
+
+		select {
+		case <-c.ctx.Done():
+			return c.ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// parseRestoreStatus parses the x-amz-restore header, e.g.
+// 'ongoing-request="false", expiry-date="Fri, 21 Dec 2012 00:00:00 GMT"'.
+func parseRestoreStatus(restore string) (ongoing bool, expiry time.Time) {
+	for part := range strings.SplitSeq(restore, `", `) {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			continue
+		}
+		value = strings.Trim(value, `"`)
+
+		switch strings.TrimSpace(key) {
+		case "ongoing-request":
+			ongoing = value == "true"
+		case "expiry-date":
+			expiry, _ = time.Parse(time.RFC1123, value)
+		}
+	}
+	return ongoing, expiry
+}
+
+// describeRestore returns a readable restore status, empty when no restore was requested.
+func describeRestore(restore *string) string {
+	if restore == nil {
+		return ""
+	}
+
+	ongoing, expiry := parseRestoreStatus(*restore)
+	switch {
+	case ongoing:
+		return "in progress"
+	case !expiry.IsZero():
+		return "restored until " + expiry.Local().Format(time.DateTime)
+	default:
+		return *restore
+	}
+}
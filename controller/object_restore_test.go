@@ -0,0 +1,65 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/shoenig/test/must"
+)
+
+func TestParseRestoreStatus(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		restore     string
+		wantOngoing bool
+		wantExpiry  time.Time
+	}{
+		{name: "empty"},
+		{name: "ongoing", restore: `ongoing-request="true"`, wantOngoing: true},
+		{
+			name:       "restored",
+			restore:    `ongoing-request="false", expiry-date="Fri, 21 Dec 2012 00:00:00 GMT"`,
+			wantExpiry: time.Date(2012, 12, 21, 0, 0, 0, 0, time.UTC),
+		},
+		{name: "invalid expiry", restore: `ongoing-request="false", expiry-date="tomorrow"`},
+		{name: "garbage", restore: "something"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ongoing, expiry := parseRestoreStatus(tt.restore)
+			must.Eq(t, tt.wantOngoing, ongoing)
+			must.True(t, tt.wantExpiry.Equal(expiry), must.Sprintf("expiry %v, want %v", expiry, tt.wantExpiry))
+		})
+	}
+}
+
+func TestDescribeRestore(t *testing.T) {
+	t.Parallel()
+
+	expiry := time.Date(2012, 12, 21, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		restore *string
+		want    string
+	}{
+		{name: "not requested", want: ""},
+		{name: "ongoing", restore: aws.String(`ongoing-request="true"`), want: "in progress"},
+		{
+			name:    "restored",
+			restore: aws.String(`ongoing-request="false", expiry-date="Fri, 21 Dec 2012 00:00:00 GMT"`),
+			want:    "restored until " + expiry.Local().Format(time.DateTime),
+		},
+		{name: "unknown format", restore: aws.String("something"), want: "something"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			must.Eq(t, tt.want, describeRestore(tt.restore))
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/sj14/sss/util"
+)
+
+func (c *Controller) ObjectRetentionGet(bucket, key, versionID string) error {
+	resp, err := c.client.GetObjectRetention(c.ctx, &s3.GetObjectRetentionInput{
+		Bucket:    aws.String(bucket),
+		Key:       aws.String(key),
+		VersionId: util.NilIfZero(versionID),
+	})
+	if err != nil {
+		return err
+	}
+
+	b, err := json.MarshalIndent(resp.Retention, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(c.OutWriter, string(b))
+
+	return nil
+}
+
+type ObjectRetentionConfig struct {
+	Bucket           string
+	VersionID        string
+	Delimiter        string
+	Concurrency      int
+	DryRun           bool
+	Mode             string
+	Until            time.Time
+	For              string
+	BypassGovernance bool
+}
+
+// ObjectRetentionPut sets the retention of a single object or, when the key
+// ends with the delimiter, of all objects below the prefix.
+func (c *Controller) ObjectRetentionPut(key string, cfg ObjectRetentionConfig) error {
+	mode := types.ObjectLockRetentionMode(strings.ToUpper(cfg.Mode))
+	if !slices.Contains(mode.Values(), mode) {
+		return fmt.Errorf("invalid retention mode %q (use 'GOVERNANCE' or 'COMPLIANCE')", cfg.Mode)
+	}
+
+	until := cfg.Until
+	switch {
+	case cfg.For != "" && !until.IsZero():
+		return errors.New("retention can't be set with both 'until' and 'for'")
+	case cfg.For != "":
+		d, err := parseRetentionDuration(cfg.For)
+		if err != nil {
+			return err
+		}
+		until = time.Now().Add(d)
+	case until.IsZero():
+		return errors.New("missing retention date, use 'until' or 'for'")
+	}
+
+	return c.objectEach(cfg.Bucket, key, cfg.VersionID, cfg.Delimiter, cfg.Concurrency, func(key string) error {
+		fmt.Fprintf(c.OutWriter, "setting %s retention of %s until %s\n", mode, key, until.Local().Format(time.DateTime))
+		if cfg.DryRun {
+			return nil
+		}
+
+		_, err := c.client.PutObjectRetention(c.ctx, &s3.PutObjectRetentionInput{
+			Bucket:    aws.String(cfg.Bucket),
+			Key:       aws.String(key),
+			VersionId: util.NilIfZero(cfg.VersionID),
+			Retention: &types.ObjectLockRetention{
+				Mode:            mode,
+				RetainUntilDate: aws.Time(until.UTC()),
+			},
+			BypassGovernanceRetention: util.NilIfZero(cfg.BypassGovernance),
+		})
+		return err
+	})
+}
+
+// parseRetentionDuration parses Go durations and additionally days, e.g. '30d'.
+func parseRetentionDuration(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.ParseUint(days, 10, 16)
+		if err != nil || n == 0 {
+			return 0, fmt.Errorf("invalid retention duration %q", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid retention duration %q", s)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("retention duration must be positive: %q", s)
+	}
+	return d, nil
+}
+
+func (c *Controller) ObjectLegalHoldGet(bucket, key, versionID string) error {
+	resp, err := c.client.GetObjectLegalHold(c.ctx, &s3.GetObjectLegalHoldInput{
+		Bucket:    aws.String(bucket),
+		Key:       aws.String(key),
+		VersionId: util.NilIfZero(versionID),
+	})
+	if err != nil {
+		return err
+	}
+
+	b, err := json.MarshalIndent(resp.LegalHold, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(c.OutWriter, string(b))
+
+	return nil
+}
+
+type ObjectLegalHoldConfig struct {
+	Bucket      string
+	VersionID   string
+	Delimiter   string
+	Concurrency int
+	DryRun      bool
+}
+
+// ObjectLegalHoldPut enables or disables the legal hold of a single object or,
+// when the key ends with the delimiter, of all objects below the prefix.
+func (c *Controller) ObjectLegalHoldPut(key string, on bool, cfg ObjectLegalHoldConfig) error {
+	status := types.ObjectLockLegalHoldStatusOff
+	if on {
+		status = types.ObjectLockLegalHoldStatusOn
+	}
+
+	return c.objectEach(cfg.Bucket, key, cfg.VersionID, cfg.Delimiter, cfg.Concurrency, func(key string) error {
+		fmt.Fprintf(c.OutWriter, "setting legal hold of %s %s\n", key, status)
+		if cfg.DryRun {
+			return nil
+		}
+
+		_, err := c.client.PutObjectLegalHold(c.ctx, &s3.PutObjectLegalHoldInput{
+			Bucket:    aws.String(cfg.Bucket),
+			Key:       aws.String(key),
+			VersionId: util.NilIfZero(cfg.VersionID),
+			LegalHold: &types.ObjectLockLegalHold{Status: status},
+		})
+		return err
+	})
+}
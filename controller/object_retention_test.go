@@ -0,0 +1,44 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestParseRetentionDuration(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{input: "30d", want: 30 * 24 * time.Hour},
+		{input: "1d", want: 24 * time.Hour},
+		{input: "36h", want: 36 * time.Hour},
+		{input: "90m", want: 90 * time.Minute},
+		{input: "0d", wantErr: true},
+		{input: "-1d", wantErr: true},
+		{input: "1.5d", wantErr: true},
+		{input: "99999d", wantErr: true},
+		{input: "0s", wantErr: true},
+		{input: "-1h", wantErr: true},
+		{input: "d", wantErr: true},
+		{input: "1y", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseRetentionDuration(tt.input)
+			if tt.wantErr {
+				must.Error(t, err)
+				return
+			}
+			must.NoError(t, err)
+			must.Eq(t, tt.want, got)
+		})
+	}
+}
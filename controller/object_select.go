@@ -0,0 +1,175 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/dustin/go-humanize"
+	"github.com/sj14/sss/util"
+)
+
+type ObjectSelectConfig struct {
+	Bucket string
+	SQL    string
+	// Input is 'csv', 'json' or 'parquet', detected by the key extension when empty.
+	Input string
+	// Output is 'csv' or 'json', defaults to the input format ('json' for parquet).
+	Output string
+	// Compression is 'NONE', 'GZIP' or 'BZIP2', detected by the key extension when empty.
+	Compression string
+	// CSVHeader is 'USE', 'IGNORE' or 'NONE'.
+	CSVHeader    string
+	CSVDelimiter string
+	// JSONType is 'LINES' or 'DOCUMENT'.
+	JSONType string
+	SSEC     util.SSEC
+}
+
+// ObjectSelect runs the SQL expression on the object and streams the
+// resulting records to the OutWriter. Progress and stats are written to
+// the ErrWriter with verbosity 2 or higher, to keep the output parsable.
+func (c *Controller) ObjectSelect(key string, cfg ObjectSelectConfig) error {
+	if cfg.SQL == "" {
+		return errors.New("missing SQL expression")
+	}
+
+	inputSerialization, outputSerialization, err := cfg.serialization(key)
+	if err != nil {
+		return err
+	}
+
+	input := &s3.SelectObjectContentInput{
+		Bucket:              aws.String(cfg.Bucket),
+		Key:                 aws.String(key),
+		Expression:          aws.String(cfg.SQL),
+		ExpressionType:      types.ExpressionTypeSql,
+		InputSerialization:  inputSerialization,
+		OutputSerialization: outputSerialization,
+		RequestProgress:     &types.RequestProgress{Enabled: aws.Bool(c.verbosity >= 2)},
+	}
+
+	if cfg.SSEC.KeyIsSet() {
+		input.SSECustomerAlgorithm = aws.String(cfg.SSEC.Algorithm())
+		input.SSECustomerKey = aws.String(cfg.SSEC.Base64Key())
+		input.SSECustomerKeyMD5 = aws.String(cfg.SSEC.Base64KeyMD5())
+	}
+
+	resp, err := c.client.SelectObjectContent(c.ctx, input)
+	if err != nil {
+		return err
+	}
+
+	stream := resp.GetStream()
+	defer stream.Close()
+
+	for event := range stream.Events() {
+		switch e := event.(type) {
+		case *types.SelectObjectContentEventStreamMemberRecords:
+			if _, err := c.OutWriter.Write(e.Value.Payload); err != nil {
+				return err
+			}
+		case *types.SelectObjectContentEventStreamMemberProgress:
+			if c.verbosity >= 2 && e.Value.Details != nil {
+				fmt.Fprintf(c.ErrWriter, "> progress: %s <\n", describeSelectStats(
+					e.Value.Details.BytesScanned, e.Value.Details.BytesProcessed, e.Value.Details.BytesReturned))
+			}
+		case *types.SelectObjectContentEventStreamMemberStats:
+			if c.verbosity >= 2 && e.Value.Details != nil {
+				fmt.Fprintf(c.ErrWriter, "> stats: %s <\n", describeSelectStats(
+					e.Value.Details.BytesScanned, e.Value.Details.BytesProcessed, e.Value.Details.BytesReturned))
+			}
+		}
+	}
+
+	return stream.Err()
+}
+
+func describeSelectStats(scanned, processed, returned *int64) string {
+	return fmt.Sprintf("scanned %s | processed %s | returned %s",
+		humanize.IBytes(uint64(aws.ToInt64(scanned))),
+		humanize.IBytes(uint64(aws.ToInt64(processed))),
+		humanize.IBytes(uint64(aws.ToInt64(returned))),
+	)
+}
+
+func (cfg ObjectSelectConfig) serialization(key string) (*types.InputSerialization, *types.OutputSerialization, error) {
+	ext := strings.ToLower(path.Ext(key))
+
+	compression := types.CompressionType(strings.ToUpper(cfg.Compression))
+	if compression == "" {
+		switch ext {
+		case ".gz":
+			compression = types.CompressionTypeGzip
+		case ".bz2":
+			compression = types.CompressionTypeBzip2
+		default:
+			compression = types.CompressionTypeNone
+		}
+
+		if compression != types.CompressionTypeNone {
+			// detect the format of e.g. 'logs.csv.gz'
+			ext = strings.ToLower(path.Ext(strings.TrimSuffix(strings.ToLower(key), ext)))
+		}
+	}
+
+	format := strings.ToLower(cfg.Input)
+	if format == "" {
+		switch ext {
+		case ".json", ".jsonl", ".ndjson":
+			format = "json"
+		case ".parquet":
+			format = "parquet"
+		default:
+			format = "csv"
+		}
+	}
+
+	in := &types.InputSerialization{CompressionType: compression}
+
+	switch format {
+	case "csv":
+		in.CSV = &types.CSVInput{
+			FileHeaderInfo: types.FileHeaderInfo(strings.ToUpper(cfg.CSVHeader)),
+			FieldDelimiter: util.NilIfZero(cfg.CSVDelimiter),
+		}
+	case "json":
+		jsonType := types.JSONType(strings.ToUpper(cfg.JSONType))
+		if jsonType == "" {
+			jsonType = types.JSONTypeLines
+		}
+		in.JSON = &types.JSONInput{Type: jsonType}
+	case "parquet":
+		if compression != types.CompressionTypeNone {
+			return nil, nil, errors.New("compression is not supported for parquet")
+		}
+		in.Parquet = &types.ParquetInput{}
+	default:
+		return nil, nil, fmt.Errorf("unsupported input format %q (use 'csv', 'json' or 'parquet')", cfg.Input)
+	}
+
+	output := strings.ToLower(cfg.Output)
+	if output == "" {
+		output = format
+		if output == "parquet" {
+			output = "json"
+		}
+	}
+
+	out := &types.OutputSerialization{}
+
+	switch output {
+	case "csv":
+		out.CSV = &types.CSVOutput{}
+	case "json":
+		out.JSON = &types.JSONOutput{RecordDelimiter: aws.String("\n")}
+	default:
+		return nil, nil, fmt.Errorf("unsupported output format %q (use 'csv' or 'json')", cfg.Output)
+	}
+
+	return in, out, nil
+}
@@ -0,0 +1,159 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/sj14/sss/util"
+)
+
+func (c *Controller) ObjectTagging(bucket, key, versionID string) error {
+	tags, err := c.objectTags(bucket, key, versionID)
+	if err != nil {
+		return err
+	}
+
+	b, err := json.MarshalIndent(tags, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(c.OutWriter, string(b))
+
+	return nil
+}
+
+func (c *Controller) objectTags(bucket, key, versionID string) ([]types.Tag, error) {
+	resp, err := c.client.GetObjectTagging(c.ctx, &s3.GetObjectTaggingInput{
+		Bucket:    aws.String(bucket),
+		Key:       aws.String(key),
+		VersionId: util.NilIfZero(versionID),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.TagSet == nil {
+		return []types.Tag{}, nil
+	}
+
+	return resp.TagSet, nil
+}
+
+type ObjectTaggingConfig struct {
+	Bucket      string
+	VersionID   string
+	Delimiter   string
+	Concurrency int
+	DryRun      bool
+	// Tags in the format 'key=value' for put, tag keys for rm.
+	Tags []string
+	// Merge the tags into the existing tags instead of replacing all tags.
+	Merge bool
+}
+
+// ObjectTaggingPut sets the tags of a single object or, when the key ends with
+// the delimiter, of all objects below the prefix.
+func (c *Controller) ObjectTaggingPut(key string, cfg ObjectTaggingConfig) error {
+	tags, err := parseTags(cfg.Tags)
+	if err != nil {
+		return err
+	}
+	if len(tags) == 0 {
+		return errors.New("no tags specified")
+	}
+
+	return c.objectEach(cfg.Bucket, key, cfg.VersionID, cfg.Delimiter, cfg.Concurrency, func(key string) error {
+		newTags := tags
+		if cfg.Merge {
+			existing, err := c.objectTags(cfg.Bucket, key, cfg.VersionID)
+			if err != nil {
+				return err
+			}
+			newTags = mergeTags(existing, tags)
+		}
+
+		fmt.Fprintf(c.OutWriter, "tagging %s\n", key)
+		if cfg.DryRun {
+			return nil
+		}
+
+		return c.objectTaggingPut(cfg.Bucket, key, cfg.VersionID, newTags)
+	})
+}
+
+// ObjectTaggingDelete removes the given tag keys or all tags when no keys are given.
+func (c *Controller) ObjectTaggingDelete(key string, cfg ObjectTaggingConfig) error {
+	return c.objectEach(cfg.Bucket, key, cfg.VersionID, cfg.Delimiter, cfg.Concurrency, func(key string) error {
+		fmt.Fprintf(c.OutWriter, "removing tags from %s\n", key)
+
+		if len(cfg.Tags) > 0 {
+			existing, err := c.objectTags(cfg.Bucket, key, cfg.VersionID)
+			if err != nil {
+				return err
+			}
+
+			remaining := removeTags(existing, cfg.Tags)
+			if len(remaining) > 0 {
+				if cfg.DryRun {
+					return nil
+				}
+				return c.objectTaggingPut(cfg.Bucket, key, cfg.VersionID, remaining)
+			}
+		}
+
+		if cfg.DryRun {
+			return nil
+		}
+
+		_, err := c.client.DeleteObjectTagging(c.ctx, &s3.DeleteObjectTaggingInput{
+			Bucket:    aws.String(cfg.Bucket),
+			Key:       aws.String(key),
+			VersionId: util.NilIfZero(cfg.VersionID),
+		})
+		return err
+	})
+}
+
+func (c *Controller) objectTaggingPut(bucket, key, versionID string, tags []types.Tag) error {
+	_, err := c.client.PutObjectTagging(c.ctx, &s3.PutObjectTaggingInput{
+		Bucket:    aws.String(bucket),
+		Key:       aws.String(key),
+		VersionId: util.NilIfZero(versionID),
+		Tagging:   &types.Tagging{TagSet: tags},
+	})
+	return err
+}
+
+// tagsQuery converts tags in the format 'key=value' into the URL query
+// format used by the Tagging header of uploads and copies.
+func tagsQuery(pairs []string) (*string, error) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+
+	tags, err := parseTags(pairs)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodeTags(tags), nil
+}
+
+// encodeTags returns the tags URL-encoded, as used by the 'x-amz-tagging' header.
+func encodeTags(tags []types.Tag) *string {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	values := url.Values{}
+	for _, tag := range tags {
+		values.Set(aws.ToString(tag.Key), aws.ToString(tag.Value))
+	}
+
+	return aws.String(values.Encode())
+}
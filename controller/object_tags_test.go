@@ -0,0 +1,59 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/shoenig/test/must"
+)
+
+func TestTagsQuery(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		pairs   []string
+		want    *string
+		wantErr bool
+	}{
+		{name: "empty"},
+		{name: "single", pairs: []string{"env=prod"}, want: aws.String("env=prod")},
+		{name: "sorted", pairs: []string{"team=core", "env=prod"}, want: aws.String("env=prod&team=core")},
+		{name: "escaped", pairs: []string{"path=a/b c&d"}, want: aws.String("path=a%2Fb+c%26d")},
+		{name: "invalid", pairs: []string{"env"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tagsQuery(tt.pairs)
+			if tt.wantErr {
+				must.Error(t, err)
+				return
+			}
+			must.NoError(t, err)
+			must.Eq(t, tt.want, got)
+		})
+	}
+}
+
+func TestEncodeTags(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		tags []types.Tag
+		want *string
+	}{
+		{name: "nil"},
+		{name: "empty", tags: []types.Tag{}},
+		{name: "tags", tags: []types.Tag{tag("b", "2"), tag("a", "1")}, want: aws.String("a=1&b=2")},
+		{name: "empty value", tags: []types.Tag{tag("a", "")}, want: aws.String("a=")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			must.Eq(t, tt.want, encodeTags(tt.tags))
+		})
+	}
+}
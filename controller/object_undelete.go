@@ -0,0 +1,145 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+type ObjectUndeleteConfig struct {
+	Bucket      string
+	Delimiter   string
+	AsOf        time.Time
+	Concurrency int
+	DryRun      bool
+}
+
+// ObjectUndelete restores deleted objects in versioned buckets.
+// Without AsOf, the delete markers on top of the latest object version are removed.
+// With AsOf, the version which was current at that time is copied back as the latest version.
+func (c *Controller) ObjectUndelete(prefix string, cfg ObjectUndeleteConfig) error {
+	if prefix == "" {
+		return errors.New("missing key")
+	}
+
+	// only undelete a single object when the prefix doesn't end with the delimiter
+	singleKey := !strings.HasSuffix(prefix, cfg.Delimiter)
+	if prefix == "/" {
+		prefix = ""
+	}
+
+	// group all versions by key, the listing might split a key over multiple pages
+	var (
+		keys     []string
+		versions = map[string][]versionEntry{}
+	)
+	for resp, err := range c.objectVersions(cfg.Bucket, prefix, "") {
+		if err != nil {
+			return err
+		}
+
+		for _, v := range versionEntries(resp) {
+			if singleKey && v.key != prefix {
+				continue
+			}
+			if _, ok := versions[v.key]; !ok {
+				keys = append(keys, v.key)
+			}
+			versions[v.key] = append(versions[v.key], v)
+		}
+	}
+
+	var (
+		removedMarkers   atomic.Uint64
+		restoredVersions atomic.Uint64
+		skipped          atomic.Uint64
+	)
+
+	eg, _ := errgroup.WithContext(c.ctx)
+	eg.SetLimit(cfg.Concurrency)
+
+	for _, key := range keys {
+		entries := versions[key] // newest first
+
+		if cfg.AsOf.IsZero() {
+			// the key is deleted when its latest entry is a delete marker
+			latest := slices.IndexFunc(entries, func(v versionEntry) bool { return v.isLatest })
+			if latest < 0 || !entries[latest].deleteMarker {
+				skipped.Add(1)
+				continue
+			}
+
+			// remove the latest delete marker and the ones below it, down to the newest object version
+			var markers []versionEntry
+			for _, v := range entries[latest:] {
+				if !v.deleteMarker {
+					break
+				}
+				markers = append(markers, v)
+			}
+			if len(markers) == len(entries[latest:]) {
+				// no version left to restore
+				skipped.Add(1)
+				continue
+			}
+
+			for _, marker := range markers {
+				eg.Go(func() error {
+					fmt.Fprintf(c.OutWriter, "removing delete marker %s (%s)\n", key, marker.versionID)
+					if err := c.objectDelete(cfg.DryRun, false, cfg.Bucket, key, marker.versionID); err != nil {
+						return err
+					}
+					removedMarkers.Add(1)
+					return nil
+				})
+			}
+			continue
+		}
+
+		// find the version which was current at the given time
+		idx := -1
+		for i, v := range entries {
+			if !v.lastModified.After(cfg.AsOf) {
+				idx = i
+				break
+			}
+		}
+		if idx <= 0 || entries[idx].deleteMarker {
+			// unchanged since then or not existing at that time
+			skipped.Add(1)
+			continue
+		}
+
+		version := entries[idx]
+		eg.Go(func() error {
+			fmt.Fprintf(c.OutWriter, "restoring %s (%s from %s)\n", key, version.versionID, version.lastModified.Local().Format(time.DateTime))
+			if !cfg.DryRun {
+				err := c.ObjectCopy(ObjectCopyConfig{
+					SrcBucket:    cfg.Bucket,
+					SrcKey:       key,
+					SrcVersionID: version.versionID,
+				})
+				if err != nil {
+					return fmt.Errorf("failed to restore %q: %w", key, err)
+				}
+			}
+			restoredVersions.Add(1)
+			return nil
+		})
+	}
+
+	err := eg.Wait()
+
+	fmt.Fprintf(c.OutWriter, "removed delete markers: %d | restored versions: %d | skipped objects: %d\n",
+		removedMarkers.Load(),
+		restoredVersions.Load(),
+		skipped.Load(),
+	)
+
+	return err
+}
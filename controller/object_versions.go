@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"iter"
+	"slices"
 	"strings"
 	"time"
 
@@ -22,20 +23,26 @@ func (c *Controller) ObjectVersions(bucket, prefix, originalPrefix, delimiter st
 			fmt.Fprintf(c.OutWriter, "%61s  %s\n", "PREFIX", *prefix.Prefix)
 		}
 
-		for _, v := range resp.Versions {
+		for _, v := range versionEntries(resp) {
 			if asJson {
-				b, err := json.Marshal(v)
+				b, err := json.Marshal(v.raw)
 				if err != nil {
 					return err
 				}
 				fmt.Println(string(b))
 				continue
 			}
+
+			size := humanize.IBytes(uint64(v.size))
+			if v.deleteMarker {
+				size = "DELETED"
+			}
+
 			fmt.Fprintf(c.OutWriter, "%s  %s %8s  %s\n",
-				v.LastModified.Local().Format(time.DateTime),
-				*v.VersionId,
-				humanize.IBytes(uint64(*v.Size)),
-				strings.TrimPrefix(*v.Key, originalPrefix),
+				v.lastModified.Local().Format(time.DateTime),
+				v.versionID,
+				size,
+				strings.TrimPrefix(v.key, originalPrefix),
 			)
 		}
 	}
@@ -60,3 +67,60 @@ func (c *Controller) objectVersions(bucket, prefix, delimiter string) iter.Seq2[
 		}
 	}
 }
+
+// versionEntry is either an object version or a delete marker.
+type versionEntry struct {
+	key          string
+	versionID    string
+	lastModified time.Time
+	size         int64
+	isLatest     bool
+	deleteMarker bool
+	raw          any
+}
+
+// versionEntries merges versions and delete markers, sorted by key and newest first.
+func versionEntries(resp *s3.ListObjectVersionsOutput) []versionEntry {
+	entries := make([]versionEntry, 0, len(resp.Versions)+len(resp.DeleteMarkers))
+
+	for _, v := range resp.Versions {
+		entries = append(entries, versionEntry{
+			key:          aws.ToString(v.Key),
+			versionID:    aws.ToString(v.VersionId),
+			lastModified: aws.ToTime(v.LastModified),
+			size:         aws.ToInt64(v.Size),
+			isLatest:     aws.ToBool(v.IsLatest),
+			raw:          v,
+		})
+	}
+
+	for _, m := range resp.DeleteMarkers {
+		entries = append(entries, versionEntry{
+			key:          aws.ToString(m.Key),
+			versionID:    aws.ToString(m.VersionId),
+			lastModified: aws.ToTime(m.LastModified),
+			isLatest:     aws.ToBool(m.IsLatest),
+			deleteMarker: true,
+			raw:          m,
+		})
+	}
+
+	slices.SortStableFunc(entries, func(a, b versionEntry) int {
+		if c := strings.Compare(a.key, b.key); c != 0 {
+			return c
+		}
+		if c := b.lastModified.Compare(a.lastModified); c != 0 {
+			return c
+		}
+		// the timestamps have a resolution of one second, keep the latest entry on top
+		switch {
+		case a.isLatest && !b.isLatest:
+			return -1
+		case b.isLatest && !a.isLatest:
+			return 1
+		}
+		return 0
+	})
+
+	return entries
+}
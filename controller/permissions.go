@@ -0,0 +1,151 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"reflect"
+	"slices"
+	"strings"
+
+	smithymiddleware "github.com/aws/smithy-go/middleware"
+)
+
+// ErrPermissionDenied is returned for operations blocked by the permission policy.
+var ErrPermissionDenied = errors.New("blocked by permission policy")
+
+// relatedOperations are denied together with the operation, as they have
+// the same effect (e.g. the bulk or multipart variant of an operation).
+var relatedOperations = map[string][]string{
+	"deleteobject": {"DeleteObjects"},
+	"copyobject":   {"UploadPartCopy"},
+	"putobject":    {"CreateMultipartUpload", "UploadPart", "UploadPartCopy", "CompleteMultipartUpload"},
+}
+
+// PermissionMiddleware blocks operations based on the operation name and the
+// bucket before any request is sent.
+type PermissionMiddleware struct {
+	AllowBuckets   []string
+	DenyBuckets    []string
+	DenyOperations []string
+}
+
+func NewPermissionMiddleware(profile Profile) (*PermissionMiddleware, error) {
+	for _, pattern := range slices.Concat(profile.AllowBuckets, profile.DenyBuckets) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid bucket pattern %q: %w", pattern, err)
+		}
+	}
+	for _, pattern := range profile.DenyOperations {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid operation pattern %q: %w", pattern, err)
+		}
+	}
+
+	return &PermissionMiddleware{
+		AllowBuckets:   profile.AllowBuckets,
+		DenyBuckets:    profile.DenyBuckets,
+		DenyOperations: profile.DenyOperations,
+	}, nil
+}
+
+func (m *PermissionMiddleware) Enabled() bool {
+	return len(m.AllowBuckets) > 0 || len(m.DenyBuckets) > 0 || len(m.DenyOperations) > 0
+}
+
+func (m *PermissionMiddleware) ID() string {
+	return "PermissionPolicy"
+}
+
+func (m *PermissionMiddleware) HandleInitialize(
+	ctx context.Context,
+	in smithymiddleware.InitializeInput,
+	next smithymiddleware.InitializeHandler,
+) (
+	smithymiddleware.InitializeOutput,
+	smithymiddleware.Metadata,
+	error,
+) {
+	operation := smithymiddleware.GetOperationName(ctx)
+
+	if err := m.check(operation, inputBuckets(in.Parameters)); err != nil {
+		return smithymiddleware.InitializeOutput{}, smithymiddleware.Metadata{}, err
+	}
+
+	return next.HandleInitialize(ctx, in)
+}
+
+func (m *PermissionMiddleware) check(operation string, buckets []string) error {
+	for _, denied := range m.DenyOperations {
+		if operationDenied(denied, operation) {
+			return fmt.Errorf("%w: %s matches %q in deny_operations", ErrPermissionDenied, operation, denied)
+		}
+	}
+
+	for _, bucket := range buckets {
+		for _, pattern := range m.DenyBuckets {
+			if ok, _ := path.Match(pattern, bucket); ok {
+				return fmt.Errorf("%w: %s on bucket %q matches deny_buckets pattern %q", ErrPermissionDenied, operation, bucket, pattern)
+			}
+		}
+
+		if len(m.AllowBuckets) == 0 {
+			continue
+		}
+
+		allowed := slices.ContainsFunc(m.AllowBuckets, func(pattern string) bool {
+			ok, _ := path.Match(pattern, bucket)
+			return ok
+		})
+		if !allowed {
+			return fmt.Errorf("%w: %s on bucket %q matches no allow_buckets pattern", ErrPermissionDenied, operation, bucket)
+		}
+	}
+
+	return nil
+}
+
+// operationDenied reports whether the operation matches the case-insensitive
+// glob pattern or is related to the denied operation.
+func operationDenied(pattern, operation string) bool {
+	if ok, _ := path.Match(strings.ToLower(pattern), strings.ToLower(operation)); ok {
+		return true
+	}
+
+	return slices.ContainsFunc(relatedOperations[strings.ToLower(pattern)], func(related string) bool {
+		return strings.EqualFold(related, operation)
+	})
+}
+
+// inputBuckets extracts the target bucket and, for copy operations,
+// the source bucket from the operation input.
+func inputBuckets(params any) []string {
+	v := reflect.ValueOf(params)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	v = v.Elem()
+
+	var buckets []string
+
+	if bucket, ok := stringField(v, "Bucket"); ok {
+		buckets = append(buckets, bucket)
+	}
+
+	// format: "bucket/key" or "/bucket/key"
+	if source, ok := stringField(v, "CopySource"); ok {
+		bucket, _, _ := strings.Cut(strings.TrimPrefix(source, "/"), "/")
+		buckets = append(buckets, bucket)
+	}
+
+	return buckets
+}
+
+func stringField(v reflect.Value, name string) (string, bool) {
+	field := v.FieldByName(name)
+	if !field.IsValid() || field.Type() != reflect.TypeFor[*string]() || field.IsNil() {
+		return "", false
+	}
+	return field.Elem().String(), true
+}
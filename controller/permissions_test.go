@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestOperationDenied(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		pattern   string
+		operation string
+		want      bool
+	}{
+		{name: "exact", pattern: "DeleteObject", operation: "DeleteObject", want: true},
+		{name: "case-insensitive", pattern: "deleteobject", operation: "DeleteObject", want: true},
+		{name: "other operation", pattern: "DeleteObject", operation: "DeleteBucket", want: false},
+		{name: "bulk delete", pattern: "DeleteObject", operation: "DeleteObjects", want: true},
+		{name: "multipart copy", pattern: "CopyObject", operation: "UploadPartCopy", want: true},
+		{name: "multipart upload", pattern: "PutObject", operation: "UploadPart", want: true},
+		{name: "multipart complete", pattern: "PutObject", operation: "CompleteMultipartUpload", want: true},
+		{name: "not related", pattern: "DeleteObjects", operation: "DeleteObject", want: false},
+		{name: "glob", pattern: "Delete*", operation: "DeleteBucketPolicy", want: true},
+		{name: "glob no match", pattern: "Delete*", operation: "PutObject", want: false},
+		{name: "glob case-insensitive", pattern: "put*", operation: "PutBucketPolicy", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			must.Eq(t, tt.want, operationDenied(tt.pattern, tt.operation))
+		})
+	}
+}
+
+func TestPermissionCheck(t *testing.T) {
+	t.Parallel()
+
+	m := &PermissionMiddleware{
+		AllowBuckets:   []string{"app-*"},
+		DenyBuckets:    []string{"*-archive"},
+		DenyOperations: []string{"DeleteObject"},
+	}
+
+	tests := []struct {
+		name      string
+		operation string
+		buckets   []string
+		denied    bool
+	}{
+		{name: "allowed", operation: "PutObject", buckets: []string{"app-data"}},
+		{name: "denied operation", operation: "DeleteObjects", buckets: []string{"app-data"}, denied: true},
+		{name: "denied bucket", operation: "PutObject", buckets: []string{"app-archive"}, denied: true},
+		{name: "not allowed bucket", operation: "PutObject", buckets: []string{"other"}, denied: true},
+		{name: "not allowed copy source", operation: "CopyObject", buckets: []string{"app-data", "other"}, denied: true},
+		{name: "no bucket", operation: "ListBuckets"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := m.check(tt.operation, tt.buckets)
+			if !tt.denied {
+				must.NoError(t, err)
+				return
+			}
+			must.ErrorIs(t, err, ErrPermissionDenied)
+		})
+	}
+}
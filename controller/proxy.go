@@ -0,0 +1,91 @@
+package controller
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// proxyFunc returns a proxy selector for http.Transport.Proxy which routes all
+// requests through proxyURL, except for hosts matching the no_proxy list.
+// The transport dials the proxy itself using its DialContext, which keeps
+// the configured network (tcp4/tcp6) in place.
+func proxyFunc(proxyURL, noProxy string) (func(*http.Request) (*url.URL, error), error) {
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse proxy URL: %w", err)
+	}
+
+	switch u.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme %q (use 'http', 'https', 'socks5' or 'socks5h')", u.Scheme)
+	}
+
+	if u.Host == "" {
+		return nil, fmt.Errorf("missing host in proxy URL %q", proxyURL)
+	}
+
+	var exclusions []string
+	for entry := range strings.SplitSeq(noProxy, ",") {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry != "" {
+			exclusions = append(exclusions, entry)
+		}
+	}
+
+	return func(req *http.Request) (*url.URL, error) {
+		if bypassProxy(req.URL, exclusions) {
+			return nil, nil
+		}
+		return u, nil
+	}, nil
+}
+
+// bypassProxy reports whether the target matches any of the no_proxy entries.
+// Entries can be '*', IPs, CIDRs or domains (with or without a leading dot),
+// optionally followed by a port.
+func bypassProxy(target *url.URL, exclusions []string) bool {
+	var (
+		host = strings.ToLower(target.Hostname())
+		port = target.Port()
+		ip   = net.ParseIP(host)
+	)
+
+	for _, entry := range exclusions {
+		if entry == "*" {
+			return true
+		}
+
+		if _, network, err := net.ParseCIDR(entry); err == nil {
+			if ip != nil && network.Contains(ip) {
+				return true
+			}
+			continue
+		}
+
+		entryHost, entryPort, err := net.SplitHostPort(entry)
+		if err != nil {
+			entryHost, entryPort = entry, ""
+		}
+		if entryPort != "" && entryPort != port {
+			continue
+		}
+
+		if entryIP := net.ParseIP(entryHost); entryIP != nil {
+			if ip != nil && entryIP.Equal(ip) {
+				return true
+			}
+			continue
+		}
+
+		entryHost = strings.TrimPrefix(entryHost, ".")
+		if host == entryHost || strings.HasSuffix(host, "."+entryHost) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,44 @@
+package controller
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestBypassProxy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		target     string
+		exclusions []string
+		want       bool
+	}{
+		{name: "no exclusions", target: "https://s3.example.com", want: false},
+		{name: "wildcard", target: "https://s3.example.com", exclusions: []string{"*"}, want: true},
+		{name: "exact domain", target: "https://example.com", exclusions: []string{"example.com"}, want: true},
+		{name: "subdomain", target: "https://s3.example.com", exclusions: []string{"example.com"}, want: true},
+		{name: "leading dot", target: "https://s3.example.com", exclusions: []string{".example.com"}, want: true},
+		{name: "case-insensitive", target: "https://S3.Example.com", exclusions: []string{"example.com"}, want: true},
+		{name: "suffix without dot", target: "https://badexample.com", exclusions: []string{"example.com"}, want: false},
+		{name: "domain with matching port", target: "https://example.com:9000", exclusions: []string{"example.com:9000"}, want: true},
+		{name: "domain with other port", target: "https://example.com:9000", exclusions: []string{"example.com:443"}, want: false},
+		{name: "ip", target: "http://10.0.0.1:9000", exclusions: []string{"10.0.0.1"}, want: true},
+		{name: "other ip", target: "http://10.0.0.2:9000", exclusions: []string{"10.0.0.1"}, want: false},
+		{name: "cidr", target: "http://10.1.2.3", exclusions: []string{"10.0.0.0/8"}, want: true},
+		{name: "cidr no match", target: "http://192.168.1.1", exclusions: []string{"10.0.0.0/8"}, want: false},
+		{name: "cidr with domain", target: "http://example.com", exclusions: []string{"10.0.0.0/8"}, want: false},
+		{name: "ipv6", target: "http://[::1]:9000", exclusions: []string{"::1"}, want: true},
+		{name: "second entry", target: "https://s3.internal", exclusions: []string{"example.com", "internal"}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target, err := url.Parse(tt.target)
+			must.NoError(t, err)
+			must.Eq(t, tt.want, bypassProxy(target, tt.exclusions))
+		})
+	}
+}
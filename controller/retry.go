@@ -0,0 +1,46 @@
+package controller
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+)
+
+// configureRetries applies the retry settings of the profile, unset values keep the SDK defaults.
+func configureRetries(awsCfg *aws.Config, profile Profile) error {
+	if profile.MaxRetries != nil {
+		if *profile.MaxRetries < 0 {
+			return fmt.Errorf("max_retries must not be negative")
+		}
+		// the SDK counts the initial attempt
+		awsCfg.RetryMaxAttempts = *profile.MaxRetries + 1
+	}
+
+	if profile.RetryMode != "" {
+		mode, err := aws.ParseRetryMode(profile.RetryMode)
+		if err != nil {
+			return err
+		}
+		awsCfg.RetryMode = mode
+	}
+
+	if profile.MaxBackoff > 0 {
+		withMaxBackoff := func(o *retry.StandardOptions) {
+			o.MaxBackoff = profile.MaxBackoff
+		}
+
+		// RetryMode is ignored as soon as a retryer is set, so we have to handle it here
+		mode := awsCfg.RetryMode
+		awsCfg.Retryer = func() aws.Retryer {
+			if mode == aws.RetryModeAdaptive {
+				return retry.NewAdaptiveMode(func(o *retry.AdaptiveModeOptions) {
+					o.StandardOptions = append(o.StandardOptions, withMaxBackoff)
+				})
+			}
+			return retry.NewStandard(withMaxBackoff)
+		}
+	}
+
+	return nil
+}
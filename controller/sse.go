@@ -0,0 +1,76 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/sj14/sss/util"
+)
+
+// SSEConfig configures server-side encryption with S3 (AES256) or KMS managed keys.
+type SSEConfig struct {
+	// Algorithm is 'AES256', 'aws:kms' or 'aws:kms:dsse'.
+	Algorithm string
+	KMSKeyID  string
+	BucketKey bool
+}
+
+func (s SSEConfig) validate() error {
+	if s.Algorithm == "" {
+		if s.KMSKeyID != "" || s.BucketKey {
+			return errors.New("KMS key ID and bucket key require an encryption algorithm")
+		}
+		return nil
+	}
+
+	algorithm := types.ServerSideEncryption(s.Algorithm)
+	if !slices.Contains(algorithm.Values(), algorithm) {
+		return fmt.Errorf("invalid encryption algorithm %q (use 'AES256' or 'aws:kms')", s.Algorithm)
+	}
+	if s.KMSKeyID != "" && !strings.HasPrefix(s.Algorithm, "aws:kms") {
+		return errors.New("KMS key ID requires the 'aws:kms' encryption algorithm")
+	}
+
+	return nil
+}
+
+// validateEncryption rejects combining SSE-C with SSE-S3 or SSE-KMS.
+func validateEncryption(sse SSEConfig, ssec util.SSEC) error {
+	if sse.Algorithm != "" && ssec.KeyIsSet() {
+		return errors.New("server-side encryption and SSE-C can't be combined")
+	}
+	return sse.validate()
+}
+
+// describeEncryption returns a readable description of the encryption in effect.
+func describeEncryption(sse types.ServerSideEncryption, kmsKeyID *string, bucketKey *bool, ssecAlgorithm *string) string {
+	var description string
+
+	switch {
+	case ssecAlgorithm != nil:
+		return fmt.Sprintf("SSE-C (%s)", *ssecAlgorithm)
+	case sse == "":
+		return "none"
+	case sse == types.ServerSideEncryptionAes256:
+		description = "SSE-S3 (AES256)"
+	case sse == types.ServerSideEncryptionAwsKms:
+		description = "SSE-KMS"
+	case sse == types.ServerSideEncryptionAwsKmsDsse:
+		description = "DSSE-KMS"
+	default:
+		description = string(sse)
+	}
+
+	if kmsKeyID != nil {
+		description += ", key " + *kmsKeyID
+	}
+	if aws.ToBool(bucketKey) {
+		description += ", bucket key enabled"
+	}
+
+	return description
+}
@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// tlsConfig creates the TLS client configuration for the given profile.
+func tlsConfig(profile Profile) (*tls.Config, error) {
+	cfg := &tls.Config{
+		InsecureSkipVerify: profile.Insecure,
+		ServerName:         profile.SNI,
+	}
+
+	if profile.TLSMinVersion != "" {
+		version, err := parseTLSVersion(profile.TLSMinVersion)
+		if err != nil {
+			return nil, err
+		}
+		cfg.MinVersion = version
+	}
+
+	if profile.CAFile != "" {
+		pem, err := os.ReadFile(profile.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+
+		// extend the system pool, the endpoint might still use a public CA
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no valid PEM certificates found in CA file %q", profile.CAFile)
+		}
+		cfg.RootCAs = pool
+	}
+
+	if profile.ClientCertFile != "" || profile.ClientKeyFile != "" {
+		if profile.ClientCertFile == "" || profile.ClientKeyFile == "" {
+			return nil, errors.New("client_cert_file and client_key_file have to be set together")
+		}
+
+		cert, err := tls.LoadX509KeyPair(profile.ClientCertFile, profile.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate %q with key %q: %w", profile.ClientCertFile, profile.ClientKeyFile, err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
+
+func parseTLSVersion(version string) (uint16, error) {
+	switch version {
+	case "1.0":
+		return tls.VersionTLS10, nil
+	case "1.1":
+		return tls.VersionTLS11, nil
+	case "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	default:
+		return 0, fmt.Errorf("unsupported TLS version %q (use '1.0', '1.1', '1.2' or '1.3')", version)
+	}
+}
+
+// wrapTLSError adds a hint to certificate verification errors.
+func wrapTLSError(err error) error {
+	var verifyErr *tls.CertificateVerificationError
+	if errors.As(err, &verifyErr) {
+		return fmt.Errorf("failed to verify server certificate chain (set 'ca_file' for private CAs): %w", err)
+	}
+	return err
+}
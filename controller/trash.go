@@ -0,0 +1,192 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	trashPrefix     = ".trash"
+	trashTimeFormat = "20060102T150405Z"
+)
+
+func isTrashKey(key string) bool {
+	return strings.HasPrefix(key, trashPrefix+"/")
+}
+
+// splitTrashKey splits ".trash/<timestamp>/<key>" into its timestamp and original key.
+func splitTrashKey(trashKey string) (time.Time, string, bool) {
+	rest, ok := strings.CutPrefix(trashKey, trashPrefix+"/")
+	if !ok {
+		return time.Time{}, "", false
+	}
+
+	dir, key, ok := strings.Cut(rest, "/")
+	if !ok {
+		return time.Time{}, "", false
+	}
+
+	ts, err := time.Parse(trashTimeFormat, dir)
+	if err != nil {
+		return time.Time{}, "", false
+	}
+
+	return ts, key, true
+}
+
+func (c *Controller) TrashList(bucket string) error {
+	for l, err := range c.objectList(bucket, trashPrefix+"/", "") {
+		if err != nil {
+			return err
+		}
+
+		for _, object := range l.Contents {
+			ts, key, ok := splitTrashKey(*object.Key)
+			if !ok {
+				continue
+			}
+
+			fmt.Fprintf(c.OutWriter, "%s %8s  %s\n",
+				ts.Format(trashTimeFormat),
+				humanize.IBytes(uint64(*object.Size)),
+				key,
+			)
+		}
+	}
+
+	return nil
+}
+
+type TrashRestoreConfig struct {
+	Bucket      string
+	Concurrency int
+	DryRun      bool
+}
+
+// TrashRestore restores all objects deleted at the given timestamp
+// or the most recently deleted copy of the given key.
+func (c *Controller) TrashRestore(target string, cfg TrashRestoreConfig) error {
+	if target == "" {
+		return errors.New("missing key or timestamp")
+	}
+
+	var trashKeys []string
+
+	if _, err := time.Parse(trashTimeFormat, target); err == nil {
+		for l, err := range c.objectList(cfg.Bucket, path.Join(trashPrefix, target)+"/", "") {
+			if err != nil {
+				return err
+			}
+			for _, object := range l.Contents {
+				trashKeys = append(trashKeys, *object.Key)
+			}
+		}
+	} else {
+		var latest time.Time
+		for l, err := range c.objectList(cfg.Bucket, trashPrefix+"/", "") {
+			if err != nil {
+				return err
+			}
+			for _, object := range l.Contents {
+				ts, key, ok := splitTrashKey(*object.Key)
+				if !ok || key != target || ts.Before(latest) {
+					continue
+				}
+				latest = ts
+				trashKeys = []string{*object.Key}
+			}
+		}
+	}
+
+	if len(trashKeys) == 0 {
+		return fmt.Errorf("nothing found in trash for %q", target)
+	}
+
+	eg, _ := errgroup.WithContext(c.ctx)
+	eg.SetLimit(cfg.Concurrency)
+
+	for _, trashKey := range trashKeys {
+		_, key, _ := splitTrashKey(trashKey)
+
+		eg.Go(func() error {
+			fmt.Fprintf(c.OutWriter, "restoring %s\n", key)
+			if cfg.DryRun {
+				return nil
+			}
+
+			err := c.ObjectCopy(ObjectCopyConfig{
+				SrcBucket: cfg.Bucket,
+				SrcKey:    trashKey,
+				DstKey:    key,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to restore %q: %w", key, err)
+			}
+
+			return c.objectDelete(false, false, cfg.Bucket, trashKey, "")
+		})
+	}
+
+	return eg.Wait()
+}
+
+type TrashPurgeConfig struct {
+	Bucket      string
+	OlderThan   time.Duration
+	Concurrency int
+	DryRun      bool
+}
+
+// TrashPurge permanently deletes all trash directories older than the given duration.
+func (c *Controller) TrashPurge(cfg TrashPurgeConfig) error {
+	var (
+		threshold = time.Now().Add(-cfg.OlderThan)
+		dirs      []string
+	)
+
+	for l, err := range c.objectList(cfg.Bucket, trashPrefix+"/", "/") {
+		if err != nil {
+			return err
+		}
+
+		for _, prefix := range l.CommonPrefixes {
+			dir := path.Base(*prefix.Prefix)
+			ts, err := time.Parse(trashTimeFormat, dir)
+			if err != nil || ts.After(threshold) {
+				continue
+			}
+			dirs = append(dirs, *prefix.Prefix)
+		}
+	}
+
+	slices.Sort(dirs)
+
+	if len(dirs) > 0 && !cfg.DryRun {
+		err := c.confirm(cfg.Bucket, fmt.Sprintf("Permanently deleting %d trash directories of bucket %q.", len(dirs), cfg.Bucket))
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, dir := range dirs {
+		err := c.ObjectDelete(dir, ObjectDeleteConfig{
+			Bucket:      cfg.Bucket,
+			Delimiter:   "/",
+			Concurrency: cfg.Concurrency,
+			DryRun:      cfg.DryRun,
+			Confirmed:   true,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package e2e
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/shoenig/test/must"
+	"github.com/sj14/sss/controller"
+)
+
+func TestAudit(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip("skipping e2e tests")
+	}
+
+	bucketName := createBucket(t)
+
+	audited := localstackProfile(t)
+	audited.AuditLog = filepath.Join(t.TempDir(), "audit.jsonl")
+
+	disabled := localstackProfile(t)
+	disabled.AuditLog = controller.AuditLogDisabled
+
+	denied := audited
+	denied.DenyOperations = []string{"Delete*"}
+
+	configPath := writeConfig(t, map[string]controller.Profile{
+		"audited":  audited,
+		"disabled": disabled,
+		"denied":   denied,
+	})
+
+	t.Run("upload", func(t *testing.T) {
+		_, err := runConfig(t.Context(), configPath, "audited", "bucket", bucketName, "put", "../README.md", "test/README.md")
+		must.NoError(t, err)
+	})
+
+	t.Run("download", func(t *testing.T) {
+		_, err := runConfig(t.Context(), configPath, "audited", "bucket", bucketName, "get", "test/README.md", filepath.Join(t.TempDir(), "README.md"))
+		must.NoError(t, err)
+	})
+
+	t.Run("denied delete", func(t *testing.T) {
+		_, err := runConfig(t.Context(), configPath, "denied", "bucket", bucketName, "rm", "test/README.md")
+		must.ErrorContains(t, err, "blocked by permission policy")
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		_, err := runConfig(t.Context(), configPath, "audited", "bucket", bucketName, "rm", "test/README.md")
+		must.NoError(t, err)
+	})
+
+	t.Run("list audit log", func(t *testing.T) {
+		out, err := runConfig(t.Context(), configPath, "audited", "audit", "ls")
+		must.NoError(t, err)
+		must.StrContains(t, out, "PutObject")
+		must.StrContains(t, out, "DeleteObject")
+		must.StrContains(t, out, bucketName+"/test/README.md  OK")
+		must.StrContains(t, out, bucketName+"/test/README.md  Denied")
+		must.StrNotContains(t, out, "GetObject")
+	})
+
+	t.Run("list audit log as JSON", func(t *testing.T) {
+		out, err := runConfig(t.Context(), configPath, "audited", "audit", "ls", "--json")
+		must.NoError(t, err)
+		must.StrContains(t, out, `"profile":"audited"`)
+		must.StrContains(t, out, `"operation":"PutObject"`)
+	})
+
+	t.Run("list disabled audit log", func(t *testing.T) {
+		_, err := runConfig(t.Context(), configPath, "disabled", "audit", "ls")
+		must.ErrorContains(t, err, "audit log is disabled")
+	})
+}
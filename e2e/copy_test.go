@@ -0,0 +1,98 @@
+package e2e
+
+import (
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestCopyPrefix(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip("skipping e2e tests")
+	}
+
+	bucketName := createBucket(t)
+
+	t.Run("upload", func(t *testing.T) {
+		_, err := run(t.Context(), "bucket", bucketName, "put", "../util/", "src")
+		must.NoError(t, err)
+	})
+
+	t.Run("copy prefix (dry-run)", func(t *testing.T) {
+		out, err := run(t.Context(), "bucket", bucketName, "cp", "src/", bucketName, "dst/", "--dry-run")
+		must.NoError(t, err)
+		must.StrContains(t, out, "copying src/util/zero.go to "+bucketName+"/dst/util/zero.go")
+	})
+
+	t.Run("list after dry-run", func(t *testing.T) {
+		out, err := run(t.Context(), "bucket", bucketName, "ls")
+		must.NoError(t, err)
+		must.StrNotContains(t, out, "dst/")
+	})
+
+	t.Run("copy prefix with filters", func(t *testing.T) {
+		out, err := run(t.Context(), "bucket", bucketName, "cp", "src/", bucketName, "dst/", "--include=util/*", "--exclude=*/zero.go")
+		must.NoError(t, err)
+		must.StrNotContains(t, out, "progress/reader.go to")
+		must.StrNotContains(t, out, "zero.go to")
+		must.StrContains(t, out, "skipped objects: ")
+	})
+
+	t.Run("list after copy", func(t *testing.T) {
+		out, err := run(t.Context(), "bucket", bucketName, "ls", "dst/util/")
+		must.NoError(t, err)
+		must.StrContains(t, out, "rand.go")
+		must.StrNotContains(t, out, "progress/")
+		must.StrNotContains(t, out, "zero.go")
+	})
+
+	t.Run("copy prefix onto itself", func(t *testing.T) {
+		_, err := run(t.Context(), "bucket", bucketName, "cp", "src/", bucketName, "src/")
+		must.ErrorContains(t, err, "source and destination are the same")
+	})
+
+	t.Run("copy prefix into itself", func(t *testing.T) {
+		out, err := run(t.Context(), "bucket", bucketName, "cp", "src/", bucketName, "src/nested/")
+		must.NoError(t, err)
+		must.StrNotContains(t, out, "copying src/nested/")
+	})
+
+	t.Run("copy prefix with version", func(t *testing.T) {
+		_, err := run(t.Context(), "bucket", bucketName, "cp", "src/", bucketName, "dst/", "--src-version-id=v1")
+		must.ErrorContains(t, err, "version can't be used with a prefix")
+	})
+
+	t.Run("copy prefix to key", func(t *testing.T) {
+		_, err := run(t.Context(), "bucket", bucketName, "cp", "src/", bucketName, "dst")
+		must.ErrorContains(t, err, "has to end with the delimiter")
+	})
+}
+
+func TestCopySpecialKey(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip("skipping e2e tests")
+	}
+
+	bucketName := createBucket(t)
+
+	const key = "dir with space/a+b?c%d#e ü.md"
+
+	t.Run("upload", func(t *testing.T) {
+		_, err := run(t.Context(), "bucket", bucketName, "put", "../README.md", key)
+		must.NoError(t, err)
+	})
+
+	t.Run("copy", func(t *testing.T) {
+		_, err := run(t.Context(), "bucket", bucketName, "cp", key, bucketName, "copy/"+key)
+		must.NoError(t, err)
+	})
+
+	t.Run("head copy", func(t *testing.T) {
+		_, err := run(t.Context(), "bucket", bucketName, "head", "copy/"+key)
+		must.NoError(t, err)
+	})
+}
@@ -3,12 +3,15 @@ package e2e
 import (
 	"context"
 	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"testing"
 
+	"github.com/BurntSushi/toml"
 	"github.com/shoenig/test/must"
 	"github.com/sj14/sss/cli"
+	"github.com/sj14/sss/controller"
 	"github.com/sj14/sss/util"
 )
 
@@ -30,10 +33,15 @@ func (w *safeWriter) Write(p []byte) (int, error) {
 var runMutex sync.Mutex
 
 func run(ctx context.Context, args ...string) (string, error) {
+	return runConfig(ctx, "config.toml", "localstack", args...)
+}
+
+// runConfig runs the command with the profile of the given config file.
+func runConfig(ctx context.Context, configPath, profile string, args ...string) (string, error) {
 	writer := &safeWriter{}
 
 	runMutex.Lock()
-	os.Args = append([]string{"sss", "--config=config.toml", "--profile=localstack"}, args...)
+	os.Args = append([]string{"sss", "--config=" + configPath, "--profile=" + profile}, args...)
 	err := cli.Exec(ctx, writer, writer, util.BuildInfo{Version: "e2e-test"})
 	runMutex.Unlock()
 
@@ -62,3 +70,24 @@ func createBucket(t *testing.T) string {
 
 	return bucketName
 }
+
+// writeConfig writes a config file with the given profiles into a temporary directory.
+func writeConfig(t *testing.T, profiles map[string]controller.Profile) string {
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+
+	f, err := os.Create(configPath)
+	must.NoError(t, err)
+	defer f.Close()
+
+	must.NoError(t, toml.NewEncoder(f).Encode(controller.Config{Profiles: profiles}))
+
+	return configPath
+}
+
+// localstackProfile returns the profile used by run.
+func localstackProfile(t *testing.T) controller.Profile {
+	config, err := controller.LoadConfig("config.toml")
+	must.NoError(t, err)
+
+	return config.Profiles["localstack"]
+}
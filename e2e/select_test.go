@@ -0,0 +1,39 @@
+package e2e
+
+import (
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestSelect(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip("skipping e2e tests")
+	}
+
+	bucketName := createBucket(t)
+
+	const sql = "--sql=SELECT * FROM s3object s"
+
+	t.Run("compressed parquet by extension", func(t *testing.T) {
+		_, err := run(t.Context(), "bucket", bucketName, "select", "data.parquet.gz", sql)
+		must.ErrorContains(t, err, "compression is not supported for parquet")
+	})
+
+	t.Run("compressed parquet by flag", func(t *testing.T) {
+		_, err := run(t.Context(), "bucket", bucketName, "select", "data.parquet", sql, "--compression=GZIP")
+		must.ErrorContains(t, err, "compression is not supported for parquet")
+	})
+
+	t.Run("unsupported input", func(t *testing.T) {
+		_, err := run(t.Context(), "bucket", bucketName, "select", "data.csv", sql, "--input=xml")
+		must.ErrorContains(t, err, `unsupported input format "xml"`)
+	})
+
+	t.Run("unsupported output", func(t *testing.T) {
+		_, err := run(t.Context(), "bucket", bucketName, "select", "data.csv", sql, "--output=xml")
+		must.ErrorContains(t, err, `unsupported output format "xml"`)
+	})
+}
@@ -0,0 +1,187 @@
+package e2e
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/shoenig/test/must"
+	"github.com/sj14/sss/controller"
+)
+
+func TestTLS(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip("skipping e2e tests")
+	}
+
+	// answers every request with an empty bucket list
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = w.Write([]byte(`<ListAllMyBucketsResult xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Buckets></Buckets></ListAllMyBucketsResult>`))
+	})
+
+	server := httptest.NewUnstartedServer(handler)
+	server.TLS = &tls.Config{MaxVersion: tls.VersionTLS12}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	dir := t.TempDir()
+
+	caPath := filepath.Join(dir, "ca.pem")
+	must.NoError(t, os.WriteFile(caPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0o600))
+
+	invalidCAPath := filepath.Join(dir, "invalid.pem")
+	must.NoError(t, os.WriteFile(invalidCAPath, []byte("no certificate"), 0o600))
+
+	profile := func(modify func(p *controller.Profile)) controller.Profile {
+		p := controller.Profile{
+			Endpoint:  server.URL,
+			Region:    "auto",
+			AccessKey: "SOMETHING_SOMETHING",
+			SecretKey: "SOMETHING_SOMETHING_SOMETHING_SOMETHING",
+			PathStyle: true,
+		}
+		modify(&p)
+		return p
+	}
+
+	configPath := writeConfig(t, map[string]controller.Profile{
+		"untrusted":  profile(func(p *controller.Profile) {}),
+		"insecure":   profile(func(p *controller.Profile) { p.Insecure = true }),
+		"trusted":    profile(func(p *controller.Profile) { p.CAFile = caPath }),
+		"tls13":      profile(func(p *controller.Profile) { p.CAFile = caPath; p.TLSMinVersion = "1.3" }),
+		"tls14":      profile(func(p *controller.Profile) { p.TLSMinVersion = "1.4" }),
+		"missing-ca": profile(func(p *controller.Profile) { p.CAFile = filepath.Join(dir, "missing.pem") }),
+		"invalid-ca": profile(func(p *controller.Profile) { p.CAFile = invalidCAPath }),
+		"cert-only":  profile(func(p *controller.Profile) { p.ClientCertFile = caPath }),
+	})
+
+	t.Run("untrusted certificate", func(t *testing.T) {
+		_, err := runConfig(t.Context(), configPath, "untrusted", "buckets")
+		must.ErrorContains(t, err, "set 'ca_file' for private CAs")
+	})
+
+	t.Run("insecure", func(t *testing.T) {
+		_, err := runConfig(t.Context(), configPath, "insecure", "buckets")
+		must.NoError(t, err)
+	})
+
+	t.Run("trusted CA file", func(t *testing.T) {
+		_, err := runConfig(t.Context(), configPath, "trusted", "buckets")
+		must.NoError(t, err)
+	})
+
+	t.Run("minimum TLS version not supported by server", func(t *testing.T) {
+		_, err := runConfig(t.Context(), configPath, "tls13", "buckets")
+		must.ErrorContains(t, err, "protocol version")
+	})
+
+	t.Run("invalid TLS version", func(t *testing.T) {
+		_, err := runConfig(t.Context(), configPath, "tls14", "buckets")
+		must.ErrorContains(t, err, `unsupported TLS version "1.4"`)
+	})
+
+	t.Run("missing CA file", func(t *testing.T) {
+		_, err := runConfig(t.Context(), configPath, "missing-ca", "buckets")
+		must.ErrorContains(t, err, "failed to read CA file")
+	})
+
+	t.Run("invalid CA file", func(t *testing.T) {
+		_, err := runConfig(t.Context(), configPath, "invalid-ca", "buckets")
+		must.ErrorContains(t, err, "no valid PEM certificates found")
+	})
+
+	t.Run("client certificate without key", func(t *testing.T) {
+		_, err := runConfig(t.Context(), configPath, "cert-only", "buckets")
+		must.ErrorContains(t, err, "client_cert_file and client_key_file have to be set together")
+	})
+}
+
+func TestTLSClientCertificate(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip("skipping e2e tests")
+	}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = w.Write([]byte(`<ListAllMyBucketsResult xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Buckets></Buckets></ListAllMyBucketsResult>`))
+	})
+
+	server := httptest.NewUnstartedServer(handler)
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	certPath, keyPath := writeClientCertificate(t)
+
+	configPath := writeConfig(t, map[string]controller.Profile{
+		"without-cert": {
+			Endpoint:  server.URL,
+			Region:    "auto",
+			PathStyle: true,
+			Insecure:  true,
+		},
+		"with-cert": {
+			Endpoint:       server.URL,
+			Region:         "auto",
+			PathStyle:      true,
+			Insecure:       true,
+			ClientCertFile: certPath,
+			ClientKeyFile:  keyPath,
+		},
+	})
+
+	t.Run("without client certificate", func(t *testing.T) {
+		_, err := runConfig(t.Context(), configPath, "without-cert", "buckets")
+		must.Error(t, err)
+	})
+
+	t.Run("with client certificate", func(t *testing.T) {
+		_, err := runConfig(t.Context(), configPath, "with-cert", "buckets")
+		must.NoError(t, err)
+	})
+}
+
+// writeClientCertificate writes a self-signed client certificate and its key.
+func writeClientCertificate(t *testing.T) (certPath, keyPath string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	must.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "sss-e2e"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	must.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	must.NoError(t, err)
+
+	dir := t.TempDir()
+	certPath = filepath.Join(dir, "client.pem")
+	keyPath = filepath.Join(dir, "client-key.pem")
+
+	must.NoError(t, os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	must.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+
+	return certPath, keyPath
+}
@@ -0,0 +1,86 @@
+package e2e
+
+import (
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestTrash(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip("skipping e2e tests")
+	}
+
+	bucketName := createBucket(t)
+
+	t.Run("upload", func(t *testing.T) {
+		_, err := run(t.Context(), "bucket", bucketName, "put", "../README.md", "test/README.md")
+		must.NoError(t, err)
+	})
+
+	t.Run("delete into trash", func(t *testing.T) {
+		out, err := run(t.Context(), "bucket", bucketName, "rm", "test/", "--trash")
+		must.NoError(t, err)
+		must.StrContains(t, out, "test/README.md")
+	})
+
+	t.Run("list after delete into trash", func(t *testing.T) {
+		out, err := run(t.Context(), "bucket", bucketName, "ls")
+		must.NoError(t, err)
+		must.StrNotContains(t, out, "test/")
+	})
+
+	t.Run("list trash", func(t *testing.T) {
+		out, err := run(t.Context(), "bucket", bucketName, "trash", "ls")
+		must.NoError(t, err)
+		must.StrContains(t, out, "test/README.md")
+	})
+
+	t.Run("restore", func(t *testing.T) {
+		out, err := run(t.Context(), "bucket", bucketName, "trash", "restore", "test/README.md")
+		must.NoError(t, err)
+		must.StrContains(t, out, "restoring test/README.md")
+	})
+
+	t.Run("list after restore", func(t *testing.T) {
+		out, err := run(t.Context(), "bucket", bucketName, "ls", "test/")
+		must.NoError(t, err)
+		must.StrContains(t, out, "README.md")
+	})
+
+	t.Run("list trash after restore", func(t *testing.T) {
+		out, err := run(t.Context(), "bucket", bucketName, "trash", "ls")
+		must.NoError(t, err)
+		must.StrNotContains(t, out, "test/README.md")
+	})
+
+	t.Run("create directory marker", func(t *testing.T) {
+		_, err := run(t.Context(), "bucket", bucketName, "cp", "test/README.md", bucketName, "marker/")
+		must.NoError(t, err)
+	})
+
+	t.Run("delete directory marker into trash", func(t *testing.T) {
+		out, err := run(t.Context(), "bucket", bucketName, "rm", "marker/", "--trash")
+		must.NoError(t, err)
+		must.StrContains(t, out, "deleting marker/ ")
+	})
+
+	t.Run("list trash with directory marker", func(t *testing.T) {
+		out, err := run(t.Context(), "bucket", bucketName, "trash", "ls")
+		must.NoError(t, err)
+		must.StrContains(t, out, "marker/\n")
+	})
+
+	t.Run("restore directory marker", func(t *testing.T) {
+		out, err := run(t.Context(), "bucket", bucketName, "trash", "restore", "marker/")
+		must.NoError(t, err)
+		must.StrContains(t, out, "restoring marker/")
+	})
+
+	t.Run("head restored directory marker", func(t *testing.T) {
+		_, err := run(t.Context(), "bucket", bucketName, "head", "marker/")
+		must.NoError(t, err)
+	})
+}
@@ -0,0 +1,96 @@
+package e2e
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestUndelete(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip("skipping e2e tests")
+	}
+
+	bucketName := createBucket(t)
+
+	t.Cleanup(func() {
+		// versions have to be removed before the bucket
+		_, err := run(context.Background(), "bucket", bucketName, "cleanup", "--all-object-versions", "--force")
+		must.NoError(t, err)
+	})
+
+	t.Run("enable versioning", func(t *testing.T) {
+		versioningPath := filepath.Join(t.TempDir(), "versioning.json")
+		must.NoError(t, os.WriteFile(versioningPath, []byte(`{"Status": "Enabled"}`), 0o600))
+
+		_, err := run(t.Context(), "bucket", bucketName, "versioning", "put", versioningPath)
+		must.NoError(t, err)
+	})
+
+	t.Run("upload", func(t *testing.T) {
+		_, err := run(t.Context(), "bucket", bucketName, "put", "../README.md", "test/README.md")
+		must.NoError(t, err)
+		_, err = run(t.Context(), "bucket", bucketName, "put", "../go.mod", "test/go.mod")
+		must.NoError(t, err)
+	})
+
+	t.Run("undelete existing", func(t *testing.T) {
+		out, err := run(t.Context(), "bucket", bucketName, "undelete", "test/README.md")
+		must.NoError(t, err)
+		must.StrContains(t, out, "removed delete markers: 0 | restored versions: 0 | skipped objects: 1")
+	})
+
+	// deleted within the same second as uploaded
+	t.Run("delete", func(t *testing.T) {
+		_, err := run(t.Context(), "bucket", bucketName, "rm", "test/", "--force")
+		must.NoError(t, err)
+	})
+
+	t.Run("list versions", func(t *testing.T) {
+		out, err := run(t.Context(), "bucket", bucketName, "versions", "test/")
+		must.NoError(t, err)
+		must.StrContains(t, out, "DELETED")
+	})
+
+	t.Run("undelete (dry-run)", func(t *testing.T) {
+		out, err := run(t.Context(), "bucket", bucketName, "undelete", "test/", "--dry-run")
+		must.NoError(t, err)
+		must.StrContains(t, out, "removing delete marker test/README.md")
+		must.StrContains(t, out, "removing delete marker test/go.mod")
+	})
+
+	t.Run("head after dry-run", func(t *testing.T) {
+		_, err := run(t.Context(), "bucket", bucketName, "head", "test/README.md")
+		must.Error(t, err)
+	})
+
+	t.Run("undelete single key", func(t *testing.T) {
+		out, err := run(t.Context(), "bucket", bucketName, "undelete", "test/README.md")
+		must.NoError(t, err)
+		must.StrContains(t, out, "removed delete markers: 1 | restored versions: 0 | skipped objects: 0")
+	})
+
+	t.Run("head after undelete", func(t *testing.T) {
+		_, err := run(t.Context(), "bucket", bucketName, "head", "test/README.md")
+		must.NoError(t, err)
+	})
+
+	t.Run("undelete prefix", func(t *testing.T) {
+		out, err := run(t.Context(), "bucket", bucketName, "undelete", "test/")
+		must.NoError(t, err)
+		must.StrContains(t, out, "removing delete marker test/go.mod")
+		must.StrContains(t, out, "removed delete markers: 1 | restored versions: 0 | skipped objects: 1")
+	})
+
+	t.Run("list after undelete", func(t *testing.T) {
+		out, err := run(t.Context(), "bucket", bucketName, "ls", "test/")
+		must.NoError(t, err)
+		must.StrContains(t, out, "README.md")
+		must.StrContains(t, out, "go.mod")
+	})
+}
@@ -0,0 +1,67 @@
+package e2e
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestWebsite(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip("skipping e2e tests")
+	}
+
+	bucketName := createBucket(t)
+
+	t.Run("put with flags", func(t *testing.T) {
+		_, err := run(t.Context(), "bucket", bucketName, "website", "put", "--index=index.html", "--error=404.html", "--redirect=docs/=documentation/")
+		must.NoError(t, err)
+	})
+
+	t.Run("get", func(t *testing.T) {
+		_, err := run(t.Context(), "bucket", bucketName, "website", "get")
+		must.NoError(t, err)
+	})
+
+	t.Run("put redirect all", func(t *testing.T) {
+		_, err := run(t.Context(), "bucket", bucketName, "website", "put", "--redirect-all-to=https://example.com")
+		must.NoError(t, err)
+	})
+
+	t.Run("put from file", func(t *testing.T) {
+		websitePath := filepath.Join(t.TempDir(), "website.json")
+		must.NoError(t, os.WriteFile(websitePath, []byte(`{"IndexDocument": {"Suffix": "index.html"}}`), 0o600))
+
+		_, err := run(t.Context(), "bucket", bucketName, "website", "put", websitePath)
+		must.NoError(t, err)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		_, err := run(t.Context(), "bucket", bucketName, "website", "rm")
+		must.NoError(t, err)
+	})
+
+	t.Run("put without index", func(t *testing.T) {
+		_, err := run(t.Context(), "bucket", bucketName, "website", "put", "--error=404.html")
+		must.ErrorContains(t, err, "missing index document")
+	})
+
+	t.Run("put file with flags", func(t *testing.T) {
+		_, err := run(t.Context(), "bucket", bucketName, "website", "put", "website.json", "--index=index.html")
+		must.ErrorContains(t, err, "configuration file and website flags can't be combined")
+	})
+
+	t.Run("put redirect all with other settings", func(t *testing.T) {
+		_, err := run(t.Context(), "bucket", bucketName, "website", "put", "--redirect-all-to=example.com", "--index=index.html")
+		must.ErrorContains(t, err, "redirecting all requests can't be combined with other website settings")
+	})
+
+	t.Run("put invalid redirect", func(t *testing.T) {
+		_, err := run(t.Context(), "bucket", bucketName, "website", "put", "--index=index.html", "--redirect=docs")
+		must.ErrorContains(t, err, "invalid redirect")
+	})
+}
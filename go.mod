@@ -13,6 +13,7 @@ require (
 	github.com/dustin/go-humanize v1.0.1
 	github.com/shoenig/test v1.12.2
 	golang.org/x/sync v0.19.0
+	golang.org/x/term v0.37.0
 	golang.org/x/time v0.14.0
 )
 
@@ -26,4 +27,5 @@ require (
 	github.com/aws/aws-sdk-go-v2/service/internal/presigned-url v1.13.16 // indirect
 	github.com/aws/aws-sdk-go-v2/service/internal/s3shared v1.19.16 // indirect
 	github.com/google/go-cmp v0.6.0 // indirect
+	golang.org/x/sys v0.38.0 // indirect
 )
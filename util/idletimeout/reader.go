@@ -0,0 +1,66 @@
+package idletimeout
+
+import (
+	"fmt"
+	"io"
+	"sync/atomic"
+	"time"
+)
+
+// Error is returned when no data was received within the idle timeout.
+// It's reported as a connection error, which makes it retryable by the SDK.
+type Error struct {
+	Duration time.Duration
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("no data received for %v, connection stalled", e.Duration)
+}
+
+func (e *Error) Timeout() bool         { return true }
+func (e *Error) Temporary() bool       { return true }
+func (e *Error) ConnectionError() bool { return true }
+
+// Reader closes the underlying reader when a single read blocks
+// for longer than the timeout.
+type Reader struct {
+	reader   io.ReadCloser
+	timeout  time.Duration
+	timer    *time.Timer
+	timedOut atomic.Bool
+}
+
+func NewReader(r io.ReadCloser, timeout time.Duration) *Reader {
+	ir := &Reader{
+		reader:  r,
+		timeout: timeout,
+	}
+
+	ir.timer = time.AfterFunc(timeout, func() {
+		ir.timedOut.Store(true)
+		ir.reader.Close() // unblock the pending read
+	})
+	ir.timer.Stop()
+
+	return ir
+}
+
+func (ir *Reader) Read(p []byte) (int, error) {
+	if ir.timedOut.Load() {
+		return 0, &Error{Duration: ir.timeout}
+	}
+
+	ir.timer.Reset(ir.timeout)
+	n, err := ir.reader.Read(p)
+	ir.timer.Stop()
+
+	if ir.timedOut.Load() {
+		return n, &Error{Duration: ir.timeout}
+	}
+	return n, err
+}
+
+func (ir *Reader) Close() error {
+	ir.timer.Stop()
+	return ir.reader.Close()
+}
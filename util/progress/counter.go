@@ -0,0 +1,22 @@
+package progress
+
+import "io"
+
+// Counter tracks transfers without a local reader or writer, e.g. server-side copies.
+type Counter struct {
+	tracker *tracker
+}
+
+func NewCounter(outputWriter io.Writer, total uint64, verbosity uint8, key string) *Counter {
+	return &Counter{
+		tracker: newTracker(outputWriter, total, verbosity, key),
+	}
+}
+
+func (c *Counter) Add(n uint64) {
+	c.tracker.add(n)
+}
+
+func (c *Counter) Finish() {
+	c.tracker.finish()
+}
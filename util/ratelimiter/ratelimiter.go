@@ -22,6 +22,11 @@ func NewReader(ctx context.Context, r io.Reader, l *rate.Limiter) *LimitReader {
 }
 
 func (lr *LimitReader) Read(p []byte) (int, error) {
+	// WaitN fails for more bytes than the burst size
+	if burst := lr.limiter.Burst(); len(p) > burst {
+		p = p[:burst]
+	}
+
 	err := lr.limiter.WaitN(lr.ctx, len(p))
 	if err != nil {
 		return 0, err
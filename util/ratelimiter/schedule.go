@@ -0,0 +1,104 @@
+package ratelimiter
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"golang.org/x/time/rate"
+)
+
+// ParseLimit parses a bandwidth per second like '10 MiB' or 'unlimited'.
+func ParseLimit(s string) (rate.Limit, error) {
+	if strings.EqualFold(strings.TrimSpace(s), "unlimited") {
+		return rate.Inf, nil
+	}
+
+	bytes, err := humanize.ParseBytes(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid bandwidth %q: %w", s, err)
+	}
+	if bytes == 0 {
+		return 0, fmt.Errorf("invalid bandwidth %q: must be greater than zero", s)
+	}
+
+	return rate.Limit(bytes), nil
+}
+
+type window struct {
+	start time.Duration // since midnight
+	end   time.Duration // since midnight, before start when wrapping around midnight
+	limit rate.Limit
+}
+
+func (w window) contains(now time.Time) bool {
+	var (
+		midnight = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		t        = now.Sub(midnight)
+	)
+
+	if w.start < w.end {
+		return t >= w.start && t < w.end
+	}
+	return t >= w.start || t < w.end
+}
+
+// Schedule contains bandwidth limits for daily time windows.
+type Schedule []window
+
+// ParseSchedule parses entries like "08:00-18:00 10MiB" or "18:00-08:00 unlimited".
+func ParseSchedule(entries []string) (Schedule, error) {
+	var schedule Schedule
+
+	for _, entry := range entries {
+		span, limit, ok := strings.Cut(strings.TrimSpace(entry), " ")
+		if !ok {
+			return nil, fmt.Errorf("invalid bandwidth schedule %q, expected format: '08:00-18:00 10MiB'", entry)
+		}
+
+		from, to, ok := strings.Cut(span, "-")
+		if !ok {
+			return nil, fmt.Errorf("invalid time range %q in bandwidth schedule, expected format: '08:00-18:00'", span)
+		}
+
+		start, err := parseTimeOfDay(from)
+		if err != nil {
+			return nil, err
+		}
+		end, err := parseTimeOfDay(to)
+		if err != nil {
+			return nil, err
+		}
+		if start == end {
+			return nil, fmt.Errorf("empty time range %q in bandwidth schedule", span)
+		}
+
+		l, err := ParseLimit(limit)
+		if err != nil {
+			return nil, err
+		}
+
+		schedule = append(schedule, window{start: start, end: end, limit: l})
+	}
+
+	return schedule, nil
+}
+
+func parseTimeOfDay(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q in bandwidth schedule, expected format: '15:04'", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// Limit returns the limit of the first window containing the given time.
+func (s Schedule) Limit(now time.Time) (rate.Limit, bool) {
+	for _, w := range s {
+		if w.contains(now) {
+			return w.limit, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,120 @@
+package ratelimiter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shoenig/test/must"
+	"golang.org/x/time/rate"
+)
+
+func TestParseLimit(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input   string
+		want    rate.Limit
+		wantErr bool
+	}{
+		{input: "10MiB", want: 10 * 1024 * 1024},
+		{input: "10 MiB", want: 10 * 1024 * 1024},
+		{input: "1kB", want: 1000},
+		{input: "unlimited", want: rate.Inf},
+		{input: " Unlimited ", want: rate.Inf},
+		{input: "0", wantErr: true},
+		{input: "fast", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseLimit(tt.input)
+			if tt.wantErr {
+				must.Error(t, err)
+				return
+			}
+			must.NoError(t, err)
+			must.Eq(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseSchedule(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		entries []string
+		want    Schedule
+		wantErr bool
+	}{
+		{name: "empty"},
+		{
+			name:    "single window",
+			entries: []string{"08:00-18:00 10MiB"},
+			want:    Schedule{{start: 8 * time.Hour, end: 18 * time.Hour, limit: 10 * 1024 * 1024}},
+		},
+		{
+			name:    "wrapping window",
+			entries: []string{"18:30-08:00 unlimited"},
+			want:    Schedule{{start: 18*time.Hour + 30*time.Minute, end: 8 * time.Hour, limit: rate.Inf}},
+		},
+		{
+			name:    "limit with space",
+			entries: []string{" 08:00-18:00 10 MiB "},
+			want:    Schedule{{start: 8 * time.Hour, end: 18 * time.Hour, limit: 10 * 1024 * 1024}},
+		},
+		{name: "missing limit", entries: []string{"08:00-18:00"}, wantErr: true},
+		{name: "missing range", entries: []string{"08:00 10MiB"}, wantErr: true},
+		{name: "invalid time", entries: []string{"8am-18:00 10MiB"}, wantErr: true},
+		{name: "out of range time", entries: []string{"08:00-24:00 10MiB"}, wantErr: true},
+		{name: "empty range", entries: []string{"08:00-08:00 10MiB"}, wantErr: true},
+		{name: "invalid limit", entries: []string{"08:00-18:00 fast"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSchedule(tt.entries)
+			if tt.wantErr {
+				must.Error(t, err)
+				return
+			}
+			must.NoError(t, err)
+			must.Eq(t, tt.want, got)
+		})
+	}
+}
+
+func TestScheduleLimit(t *testing.T) {
+	t.Parallel()
+
+	schedule, err := ParseSchedule([]string{"08:00-18:00 10MiB", "22:00-06:00 unlimited"})
+	must.NoError(t, err)
+
+	at := func(hour, minute int) time.Time {
+		return time.Date(2025, 1, 1, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name   string
+		now    time.Time
+		want   rate.Limit
+		wantOk bool
+	}{
+		{name: "window start", now: at(8, 0), want: 10 * 1024 * 1024, wantOk: true},
+		{name: "within window", now: at(12, 0), want: 10 * 1024 * 1024, wantOk: true},
+		{name: "window end is exclusive", now: at(18, 0), wantOk: false},
+		{name: "before midnight", now: at(23, 0), want: rate.Inf, wantOk: true},
+		{name: "after midnight", now: at(1, 0), want: rate.Inf, wantOk: true},
+		{name: "no window", now: at(7, 0), wantOk: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := schedule.Limit(tt.now)
+			must.Eq(t, tt.wantOk, ok)
+			if ok {
+				must.Eq(t, tt.want, got)
+			}
+		})
+	}
+}